@@ -1,10 +1,13 @@
 package main
 
 import (
-	"amirhossein-fzl/passgen/internal"
-	"fmt"
+	"context"
 	"os"
-	"slices"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/amirhossein-fzl/passgen/internal"
 )
 
 var (
@@ -13,45 +16,38 @@ var (
 	date    = "unknown"
 )
 
-func main() {
-	if slices.Contains(os.Args, "-v") || slices.Contains(os.Args, "--version") {
-		internal.PrintVersion(version, commit, date)
-		os.Exit(0)
-	}
+// interruptGracePeriod is how long a cancelled run may take to clean up, e.g.
+// to clear the clipboard, before the process exits anyway.
+const interruptGracePeriod = 2 * time.Second
 
-	cmd, err := internal.InitializeCommandLine()
+// exitInterrupted is the exit code of shells for processes killed by SIGINT.
+const exitInterrupted = 130
 
-	if err != nil {
-		fmt.Println(err.Error())
-		os.Exit(64)
-	}
-
-	err = cmd.Validate()
-
-	if err != nil {
-		fmt.Println(err.Error())
-		os.Exit(64)
-	}
-
-	options := *cmd.ToPasswordGeneratorOptions()
-	password, err := internal.GeneratePassword(options)
-
-	if err != nil {
-		fmt.Println(err.Error())
-		os.Exit(74)
+func main() {
+	internal.Version, internal.Commit, internal.Date = version, commit, date
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	code := make(chan int, 1)
+	go func() {
+		code <- internal.Run(ctx, os.Args[1:], os.Environ(), os.Stdin, os.Stdout, os.Stderr)
+	}()
+
+	select {
+	case exitCode := <-code:
+		stop()
+		os.Exit(exitCode)
+	case <-ctx.Done():
+		// A second signal kills the process right away.
+		stop()
 	}
 
-	if options.QrCode {
-		qrcode, err := internal.NewQrCode(password, 1)
-
-		if err != nil {
-			fmt.Println(err.Error())
-			os.Exit(74)
-		}
-
-		fmt.Println(qrcode.GenerateAnisUtf8i())
-		fmt.Print("Password: ")
+	// Run ignores ctx while blocked on stdin, so do not wait for it forever.
+	select {
+	case exitCode := <-code:
+		os.Exit(exitCode)
+	case <-time.After(interruptGracePeriod):
+		os.Exit(exitInterrupted)
 	}
-
-	fmt.Println(password)
 }
@@ -1,8 +1,9 @@
-module amirhossein-fzl/passgen
+module github.com/amirhossein-fzl/passgen
 
 go 1.24.4
 
 require (
+	github.com/BurntSushi/toml v1.6.0
 	github.com/skip2/go-qrcode v0.0.0-20200617195104-da1b6568686e
 	github.com/stretchr/testify v1.11.1
 )
@@ -0,0 +1,88 @@
+package internal
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/amirhossein-fzl/passgen/pkg/passgen"
+)
+
+const CheckCommand = "check"
+
+var (
+	ErrCheckPasswordArgument = errors.New("Passwords are read from stdin, never from the command line.")
+	ErrCheckPasswordMissing  = errors.New("Expected a password on stdin.")
+)
+
+// RunCheck rates a password read from stdin. Reading it from argv would leak
+// it into the shell history and the process list.
+func RunCheck(args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	options := newCommandLineOptions(CheckCommand)
+	flagSet := newCheckFlagSet(stderr, options)
+
+	if err := flagSet.Parse(args); err != nil {
+		return err
+	}
+
+	if flagSet.NArg() > 0 {
+		return ErrCheckPasswordArgument
+	}
+
+	if options.format != FormatText && options.format != FormatJSON {
+		return ErrUnknownOutputFormat
+	}
+
+	input, err := bufio.NewReader(stdin).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return err
+	}
+
+	password := strings.TrimRight(input, "\r\n")
+	if password == "" {
+		return ErrCheckPasswordMissing
+	}
+
+	report := passgen.CheckPassword(password)
+
+	if options.format == FormatJSON {
+		encoder := json.NewEncoder(stdout)
+		encoder.SetEscapeHTML(false)
+		return encoder.Encode(report)
+	}
+
+	writeStrengthReport(stdout, report)
+	return nil
+}
+
+func newCheckFlagSet(stderr io.Writer, options *CommandLineOptions) *flag.FlagSet {
+	flags := checkFlags(options)
+	flagSet := newFlagSet(CheckCommand, flags, stderr)
+
+	flagSet.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: %s %s [options] < password\n\n", ProgramName, CheckCommand)
+		fmt.Fprintf(stderr, "Estimate the strength of an existing password read from stdin.\n\n")
+		fmt.Fprintf(stderr, "Options:\n")
+		printFlags(stderr, flags)
+	}
+
+	return flagSet
+}
+
+func writeStrengthReport(w io.Writer, report passgen.StrengthReport) {
+	fmt.Fprintf(w, "Score: %d/4 (%s)\n", report.Score, report.Rating)
+	fmt.Fprintf(w, "Length: %d\n", report.Length)
+	fmt.Fprintf(w, "Classes: %s\n", strings.Join(report.Classes, ", "))
+	fmt.Fprintf(w, "Entropy: %.2f bits (estimated crack time: %s)\n", report.Entropy, report.CrackTime)
+
+	if len(report.Reasons) > 0 {
+		fmt.Fprintln(w, "Reasons:")
+		for _, reason := range report.Reasons {
+			fmt.Fprintf(w, "  - %s\n", reason)
+		}
+	}
+}
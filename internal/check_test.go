@@ -0,0 +1,87 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"strings"
+	"testing"
+
+	"github.com/amirhossein-fzl/passgen/pkg/passgen"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunCheck(t *testing.T) {
+	t.Run("text report", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+
+		err := RunCheck(nil, strings.NewReader("secret123\n"), &stdout, &stderr)
+
+		require.NoError(t, err)
+		assert.Equal(t, strings.Join([]string{
+			"Score: 2/4 (fair)",
+			"Length: 9",
+			"Classes: lowercase, numbers",
+			"Entropy: 36.19 bits (estimated crack time: 4 seconds)",
+			"Reasons:",
+			"  - Shorter than the recommended 12 characters.",
+			"  - Uses only 2 character classes.",
+			`  - Contains the sequence "123".`,
+			"",
+		}, "\n"), stdout.String())
+	})
+
+	t.Run("JSON report", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+
+		err := RunCheck([]string{"--format", "json"}, strings.NewReader("h7$Kq2!vN9@xLp4&"), &stdout, &stderr)
+
+		require.NoError(t, err)
+		var report passgen.StrengthReport
+		require.NoError(t, json.Unmarshal(stdout.Bytes(), &report))
+		assert.Equal(t, passgen.CheckPassword("h7$Kq2!vN9@xLp4&"), report)
+	})
+
+	t.Run("keeps spaces", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+
+		err := RunCheck(nil, strings.NewReader(" a b \r\n"), &stdout, &stderr)
+
+		require.NoError(t, err)
+		assert.Contains(t, stdout.String(), "Length: 5\n")
+	})
+
+	t.Run("rejects passwords in arguments", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+
+		err := RunCheck([]string{"hunter2"}, strings.NewReader(""), &stdout, &stderr)
+
+		assert.Equal(t, ErrCheckPasswordArgument, err)
+	})
+
+	t.Run("empty stdin", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+
+		err := RunCheck(nil, strings.NewReader("\n"), &stdout, &stderr)
+
+		assert.Equal(t, ErrCheckPasswordMissing, err)
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+
+		err := RunCheck([]string{"-f", "csv"}, strings.NewReader("secret"), &stdout, &stderr)
+
+		assert.Equal(t, ErrUnknownOutputFormat, err)
+	})
+
+	t.Run("help", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+
+		err := RunCheck([]string{"--help"}, strings.NewReader(""), &stdout, &stderr)
+
+		assert.Equal(t, flag.ErrHelp, err)
+		assert.Contains(t, stderr.String(), "Usage: passgen check")
+	})
+}
@@ -0,0 +1,182 @@
+package internal
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+)
+
+const (
+	ClipboardAuto    = "auto"
+	ClipboardXclip   = "xclip"
+	ClipboardXsel    = "xsel"
+	ClipboardWlCopy  = "wl-copy"
+	ClipboardPbcopy  = "pbcopy"
+	ClipboardWindows = "clip"
+	ClipboardOSC52   = "osc52"
+)
+
+var (
+	ErrUnknownClipboard                        = errors.New("Clipboard backend must be one of auto, xclip, xsel, wl-copy, pbcopy, clip or osc52.")
+	ErrClipWithCount                           = errors.New("The clipboard can only be used for a single password.")
+	ErrClipWithFormat                          = errors.New("The clipboard can only be used with the text format.")
+	ErrClipWithQr                              = errors.New("The clipboard cannot be used with a QR code in the terminal (-q), which shows the password.")
+	ErrClipboardNotReadable                    = errors.New("The clipboard cannot be read back.")
+	ErrClipTimeoutMustBeEqualOrGreaterThanZero = errors.New("Clipboard timeout must be greater than or equal to 0.")
+)
+
+// Clipboard is a place to put a secret outside of the terminal scrollback.
+// Copying an empty string clears it.
+type Clipboard interface {
+	Copy(text string) error
+}
+
+// ClipboardReader is a clipboard that can be read back, so a secret is only
+// cleared while nothing else has been copied over it.
+type ClipboardReader interface {
+	Paste() (string, error)
+}
+
+// ExecClipboard pipes the text into a clipboard command like xclip or pbcopy
+// and reads it back with the PasteCommand, if there is one.
+type ExecClipboard struct {
+	Name         string
+	Args         []string
+	PasteCommand []string
+}
+
+func (c *ExecClipboard) Copy(text string) error {
+	cmd := exec.Command(c.Name, c.Args...)
+	cmd.Stdin = strings.NewReader(text)
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%s: %w", c.Name, err)
+	}
+
+	return nil
+}
+
+func (c *ExecClipboard) Paste() (string, error) {
+	if len(c.PasteCommand) == 0 {
+		return "", ErrClipboardNotReadable
+	}
+
+	output, err := exec.Command(c.PasteCommand[0], c.PasteCommand[1:]...).Output()
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", c.PasteCommand[0], err)
+	}
+
+	// Some paste commands add a line break, which a password never ends with.
+	return strings.TrimRight(string(output), "\r\n"), nil
+}
+
+// OSC52Clipboard asks the terminal emulator to set the clipboard, which also
+// works over SSH where no local clipboard command is available.
+type OSC52Clipboard struct {
+	Terminal io.Writer
+}
+
+func (c *OSC52Clipboard) Copy(text string) error {
+	_, err := fmt.Fprintf(c.Terminal, "\033]52;c;%s\a", base64.StdEncoding.EncodeToString([]byte(text)))
+	return err
+}
+
+var execClipboards = map[string]*ExecClipboard{
+	ClipboardXclip:   {Name: "xclip", Args: []string{"-selection", "clipboard"}, PasteCommand: []string{"xclip", "-selection", "clipboard", "-o"}},
+	ClipboardXsel:    {Name: "xsel", Args: []string{"--clipboard", "--input"}, PasteCommand: []string{"xsel", "--clipboard", "--output"}},
+	ClipboardWlCopy:  {Name: "wl-copy", PasteCommand: []string{"wl-paste", "--no-newline"}},
+	ClipboardPbcopy:  {Name: "pbcopy", PasteCommand: []string{"pbpaste"}},
+	ClipboardWindows: {Name: "clip", PasteCommand: []string{"powershell", "-NoProfile", "-Command", "Get-Clipboard"}},
+}
+
+func NewClipboard(backend string, terminal io.Writer, environ []string) (Clipboard, error) {
+	switch backend {
+	case ClipboardAuto:
+		return detectClipboard(terminal, environ), nil
+	case ClipboardOSC52:
+		return &OSC52Clipboard{Terminal: terminal}, nil
+	}
+
+	clipboard, ok := execClipboards[backend]
+	if !ok {
+		return nil, ErrUnknownClipboard
+	}
+
+	return clipboard, nil
+}
+
+// detectClipboard picks the clipboard command matching the running desktop
+// and falls back to OSC 52 for remote sessions and headless machines.
+func detectClipboard(terminal io.Writer, environ []string) Clipboard {
+	var candidates []string
+
+	switch {
+	case getenv(environ, "SSH_TTY") != "":
+	case runtime.GOOS == "darwin":
+		candidates = []string{ClipboardPbcopy}
+	case runtime.GOOS == "windows":
+		candidates = []string{ClipboardWindows}
+	case getenv(environ, "WAYLAND_DISPLAY") != "":
+		candidates = []string{ClipboardWlCopy, ClipboardXclip, ClipboardXsel}
+	case getenv(environ, "DISPLAY") != "":
+		candidates = []string{ClipboardXclip, ClipboardXsel}
+	}
+
+	for _, candidate := range candidates {
+		clipboard := execClipboards[candidate]
+		if _, err := exec.LookPath(clipboard.Name); err == nil {
+			return clipboard
+		}
+	}
+
+	return &OSC52Clipboard{Terminal: terminal}
+}
+
+func isClipboardBackend(backend string) bool {
+	_, ok := execClipboards[backend]
+	return ok || backend == ClipboardAuto || backend == ClipboardOSC52
+}
+
+// WriteClipboard copies the password to the clipboard and, unless the timeout
+// is 0, blocks until the timeout expires or ctx is cancelled and clears it if
+// it still holds the password.
+func (c *CommandLineOptions) WriteClipboard(ctx context.Context, clipboard Clipboard, stderr io.Writer, passwords []string) error {
+	if len(passwords) != 1 {
+		return ErrClipWithCount
+	}
+
+	if err := clipboard.Copy(passwords[0]); err != nil {
+		return err
+	}
+
+	if c.clipTimeout == 0 {
+		fmt.Fprintln(stderr, "Copied to the clipboard.")
+		return nil
+	}
+
+	fmt.Fprintf(stderr, "Copied to the clipboard, clearing it in %s. Press Ctrl+C to clear it now.\n", c.clipTimeout)
+
+	timer := time.NewTimer(c.clipTimeout)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+
+	// Something else copied in the meantime is left alone. Clipboards that
+	// cannot be read back, like OSC 52, are always cleared.
+	if reader, ok := clipboard.(ClipboardReader); ok {
+		if text, err := reader.Paste(); err == nil && text != passwords[0] {
+			return nil
+		}
+	}
+
+	return clipboard.Copy("")
+}
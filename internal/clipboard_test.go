@@ -0,0 +1,207 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeClipboard struct {
+	history []string
+	err     error
+}
+
+func (c *fakeClipboard) Copy(text string) error {
+	if c.err != nil {
+		return c.err
+	}
+
+	c.history = append(c.history, text)
+	return nil
+}
+
+// readableClipboard is a fakeClipboard that can be read back, with changes
+// copied by someone else in between.
+type readableClipboard struct {
+	fakeClipboard
+	changed string
+}
+
+func (c *readableClipboard) Paste() (string, error) {
+	if c.changed != "" {
+		return c.changed, nil
+	}
+
+	return c.history[len(c.history)-1], nil
+}
+
+func TestCommandLineOptionsWriteClipboard(t *testing.T) {
+	t.Run("copies and clears after the timeout", func(t *testing.T) {
+		options := &CommandLineOptions{clip: true, clipTimeout: 10 * time.Millisecond}
+		clipboard := &fakeClipboard{}
+		var stderr bytes.Buffer
+
+		err := options.WriteClipboard(context.Background(), clipboard, &stderr, []string{"secret"})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"secret", ""}, clipboard.history)
+		assert.Contains(t, stderr.String(), "clearing it in 10ms")
+	})
+
+	t.Run("clears early when cancelled", func(t *testing.T) {
+		options := &CommandLineOptions{clip: true, clipTimeout: time.Hour}
+		clipboard := &fakeClipboard{}
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := options.WriteClipboard(ctx, clipboard, &bytes.Buffer{}, []string{"secret"})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"secret", ""}, clipboard.history)
+	})
+
+	t.Run("clears a clipboard that still holds the password", func(t *testing.T) {
+		options := &CommandLineOptions{clip: true, clipTimeout: time.Millisecond}
+		clipboard := &readableClipboard{}
+
+		err := options.WriteClipboard(context.Background(), clipboard, &bytes.Buffer{}, []string{"secret"})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"secret", ""}, clipboard.history)
+	})
+
+	t.Run("keeps what was copied after the password", func(t *testing.T) {
+		options := &CommandLineOptions{clip: true, clipTimeout: time.Millisecond}
+		clipboard := &readableClipboard{changed: "something else"}
+
+		err := options.WriteClipboard(context.Background(), clipboard, &bytes.Buffer{}, []string{"secret"})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"secret"}, clipboard.history)
+	})
+
+	t.Run("keeps the password without timeout", func(t *testing.T) {
+		options := &CommandLineOptions{clip: true}
+		clipboard := &fakeClipboard{}
+
+		err := options.WriteClipboard(context.Background(), clipboard, &bytes.Buffer{}, []string{"secret"})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"secret"}, clipboard.history)
+	})
+
+	t.Run("reports backend errors", func(t *testing.T) {
+		options := &CommandLineOptions{clip: true}
+		clipboard := &fakeClipboard{err: errors.New("no display")}
+
+		err := options.WriteClipboard(context.Background(), clipboard, &bytes.Buffer{}, []string{"secret"})
+
+		assert.Equal(t, clipboard.err, err)
+	})
+
+	t.Run("rejects batches", func(t *testing.T) {
+		options := &CommandLineOptions{clip: true}
+
+		err := options.WriteClipboard(context.Background(), &fakeClipboard{}, &bytes.Buffer{}, []string{"a", "b"})
+
+		assert.Equal(t, ErrClipWithCount, err)
+	})
+}
+
+func TestCommandLineOptionsWriteOutputClip(t *testing.T) {
+	options := &CommandLineOptions{length: 4, numbers: true, format: FormatText, clip: true, showEntropy: true}
+	var stdout, stderr bytes.Buffer
+
+	err := options.WriteOutput(&stdout, &stderr, []string{"1234"})
+
+	require.NoError(t, err)
+	assert.NotContains(t, stdout.String(), "1234")
+	assert.Contains(t, stdout.String(), "Entropy: ")
+}
+
+func TestOSC52Clipboard(t *testing.T) {
+	var terminal bytes.Buffer
+	clipboard := &OSC52Clipboard{Terminal: &terminal}
+
+	require.NoError(t, clipboard.Copy("secret"))
+	require.NoError(t, clipboard.Copy(""))
+
+	assert.Equal(t, "\033]52;c;c2VjcmV0\a\033]52;c;\a", terminal.String())
+}
+
+func TestExecClipboard(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("requires a POSIX shell")
+	}
+
+	path := filepath.Join(t.TempDir(), "clipboard")
+	clipboard := &ExecClipboard{Name: "sh", Args: []string{"-c", "cat > " + path}}
+
+	err := clipboard.Copy("secret")
+
+	require.NoError(t, err)
+	contents, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "secret", string(contents))
+
+	t.Run("missing command", func(t *testing.T) {
+		clipboard := &ExecClipboard{Name: "passgen-missing-clipboard"}
+
+		assert.Error(t, clipboard.Copy("secret"))
+	})
+
+	t.Run("paste", func(t *testing.T) {
+		clipboard := &ExecClipboard{Name: "sh", PasteCommand: []string{"sh", "-c", "printf 'secret\\n'"}}
+
+		text, err := clipboard.Paste()
+
+		require.NoError(t, err)
+		assert.Equal(t, "secret", text)
+	})
+
+	t.Run("without paste command", func(t *testing.T) {
+		_, err := (&ExecClipboard{Name: "sh"}).Paste()
+
+		assert.Equal(t, ErrClipboardNotReadable, err)
+	})
+}
+
+func TestNewClipboard(t *testing.T) {
+	t.Run("exec backends", func(t *testing.T) {
+		for _, backend := range []string{ClipboardXclip, ClipboardXsel, ClipboardWlCopy, ClipboardPbcopy, ClipboardWindows} {
+			clipboard, err := NewClipboard(backend, &bytes.Buffer{}, nil)
+
+			require.NoError(t, err)
+			assert.IsType(t, &ExecClipboard{}, clipboard, backend)
+		}
+	})
+
+	t.Run("OSC 52", func(t *testing.T) {
+		clipboard, err := NewClipboard(ClipboardOSC52, &bytes.Buffer{}, nil)
+
+		require.NoError(t, err)
+		assert.IsType(t, &OSC52Clipboard{}, clipboard)
+	})
+
+	t.Run("auto over SSH", func(t *testing.T) {
+		clipboard, err := NewClipboard(ClipboardAuto, &bytes.Buffer{}, []string{"DISPLAY=:0", "SSH_TTY=/dev/pts/0"})
+
+		require.NoError(t, err)
+		assert.IsType(t, &OSC52Clipboard{}, clipboard)
+	})
+
+	t.Run("unknown backend", func(t *testing.T) {
+		clipboard, err := NewClipboard("xerox", &bytes.Buffer{}, nil)
+
+		assert.Equal(t, ErrUnknownClipboard, err)
+		assert.Nil(t, clipboard)
+	})
+}
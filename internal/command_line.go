@@ -1,64 +1,196 @@
 package internal
 
 import (
+	"bytes"
+	"errors"
 	"flag"
 	"fmt"
-	"os"
-	"path/filepath"
+	"io"
+	"math"
+	"slices"
+	"strings"
 	"time"
+
+	"github.com/amirhossein-fzl/passgen/pkg/passgen"
 )
 
 const (
 	ProgramName           = "passgen"
 	DefaultPasswordLength = 12
 	DefaultAvoidRepeats   = 1
+	DefaultWordSeparator  = "-"
+	DefaultCount          = 1
+	DefaultQrMargin       = 1
+	DefaultMode           = ModeRandom
+	DefaultQrSize         = 256
+	DefaultQrLevel        = "highest"
+	DefaultQrStyle        = "ansi"
+	DefaultWifiSecurity   = passgen.WifiSecurityWPA
+	DefaultTotpBytes      = 20
+	DefaultTotpAlgorithm  = passgen.TotpAlgorithmSHA1
+	DefaultTotpDigits     = 6
+	DefaultTotpPeriod     = 30
+	DefaultClipBackend    = ClipboardAuto
+	DefaultClipTimeout    = 45 * time.Second
+	DefaultPhraseWords    = 6
+)
+
+const (
+	ModeRandom        = "random"
+	ModePronounceable = "pronounceable"
+	ModeTotp          = "totp"
+)
+
+var (
+	ErrUnknownOutputFormat = errors.New("Output format must be one of text, json or csv.")
+	ErrUnknownMode         = errors.New("Mode must be one of random, pronounceable or totp.")
+	ErrWordsWithMode       = errors.New("Passphrase words can only be used with the random mode.")
+	ErrQrFileWithCount     = errors.New("QR code files can only be written for a single password.")
+	ErrPolicyWithMode      = errors.New("Password policies can only be used with random passwords.")
+	ErrWifiWithTotp        = errors.New("Wi-Fi network options cannot be used with the totp mode.")
+	ErrWifiWithoutQr       = errors.New("Wi-Fi network options require a QR code output (-q, --qr-png or --qr-svg).")
+	ErrTotpWithoutAccount  = errors.New("TOTP secrets require an account name (--totp-account) to label them in authenticator apps.")
 )
 
 type CommandLineOptions struct {
-	length       int
-	lowercase    bool
-	uppercase    bool
-	numbers      bool
-	symbols      bool
-	custom       string
-	avoidRepeats int
-	qrOutput     bool
+	length           int
+	lengthSet        bool
+	lowercase        bool
+	uppercase        bool
+	numbers          bool
+	symbols          bool
+	custom           string
+	avoidRepeats     int
+	qrOutput         bool
+	minLowercase     int
+	minUppercase     int
+	minNumbers       int
+	minSymbols       int
+	minCustom        int
+	excludeAmbiguous bool
+	exclude          string
+	words            int
+	separator        string
+	capitalize       bool
+	withNumber       bool
+	withSymbol       bool
+	showEntropy      bool
+	count            int
+	unique           bool
+	format           string
+	mode             string
+	digits           int
+	qrPng            string
+	qrSvg            string
+	qrSize           int
+	qrLevel          string
+	qrMargin         int
+	qrStyle          string
+	wifiSsid         string
+	wifiSecurity     string
+	wifiHidden       bool
+	totpBytes        int
+	totpAlgorithm    string
+	totpDigits       int
+	totpPeriod       int
+	totpIssuer       string
+	totpAccount      string
+	clip             bool
+	clipBackend      string
+	clipTimeout      time.Duration
+	policyPath       string
+	policy           *passgen.PasswordPolicy
+	profile          string
+	insecureSeed     string
+	random           passgen.RandomSource
+	// environment names the PASSGEN_* variables the options were read from.
+	environment []string
+	// environ is the whole environment, formatted like os.Environ.
+	environ []string
 }
 
 type CommandLineParser struct {
+	command string
 	flagSet *flag.FlagSet
+	config  *Config
+	environ []string
 }
 
 func NewCommandLineParser() *CommandLineParser {
+	return newCommandLineParser(GenerateCommand)
+}
+
+// newCommandLineParser returns a parser for the generate or phrase command.
+// The phrase command only knows the passphrase and common options.
+func newCommandLineParser(command string) *CommandLineParser {
+	name := ProgramName
+	if command != GenerateCommand {
+		name += " " + command
+	}
+
 	return &CommandLineParser{
-		flagSet: flag.NewFlagSet(ProgramName, flag.ContinueOnError),
+		command: command,
+		flagSet: flag.NewFlagSet(name, flag.ContinueOnError),
 	}
 }
 
-func (p *CommandLineParser) Parse(args []string) (*CommandLineOptions, error) {
-	length := p.flagSet.Int("l", DefaultPasswordLength, "")
-	p.flagSet.IntVar(length, "length", DefaultPasswordLength, "")
-
-	lowercase := p.flagSet.Bool("L", true, "")
-	p.flagSet.BoolVar(lowercase, "lowercase", true, "")
+// WithConfig makes Parse fill in options that were not given as flags from
+// the configuration file.
+func (p *CommandLineParser) WithConfig(config *Config) *CommandLineParser {
+	p.config = config
+	return p
+}
 
-	uppercase := p.flagSet.Bool("U", true, "")
-	p.flagSet.BoolVar(uppercase, "uppercase", true, "")
+// WithEnvironment makes Parse fill in options that were not given as flags
+// from PASSGEN_* variables of environ, before the configuration file.
+func (p *CommandLineParser) WithEnvironment(environ []string) *CommandLineParser {
+	p.environ = environ
+	return p
+}
 
-	numbers := p.flagSet.Bool("N", true, "")
-	p.flagSet.BoolVar(numbers, "numbers", true, "")
+// WithOutput sets where help and flag parsing errors are written.
+func (p *CommandLineParser) WithOutput(w io.Writer) *CommandLineParser {
+	p.flagSet.SetOutput(w)
+	return p
+}
 
-	symbols := p.flagSet.Bool("S", false, "")
-	p.flagSet.BoolVar(symbols, "symbols", false, "")
+func newCommandLineOptions(command string) *CommandLineOptions {
+	options := &CommandLineOptions{
+		length:        DefaultPasswordLength,
+		lowercase:     true,
+		uppercase:     true,
+		numbers:       true,
+		avoidRepeats:  DefaultAvoidRepeats,
+		separator:     DefaultWordSeparator,
+		count:         DefaultCount,
+		format:        FormatText,
+		mode:          DefaultMode,
+		qrSize:        DefaultQrSize,
+		qrLevel:       DefaultQrLevel,
+		qrMargin:      DefaultQrMargin,
+		qrStyle:       DefaultQrStyle,
+		wifiSecurity:  DefaultWifiSecurity,
+		totpBytes:     DefaultTotpBytes,
+		totpAlgorithm: DefaultTotpAlgorithm,
+		totpDigits:    DefaultTotpDigits,
+		totpPeriod:    DefaultTotpPeriod,
+		clipBackend:   DefaultClipBackend,
+		clipTimeout:   DefaultClipTimeout,
+	}
 
-	custom := p.flagSet.String("C", "", "")
-	p.flagSet.StringVar(custom, "custom", "", "")
+	switch command {
+	case PhraseCommand:
+		options.words = DefaultPhraseWords
+	case ManCommand:
+		options.format = FormatMan
+	}
 
-	avoidRepeats := p.flagSet.Int("a", DefaultAvoidRepeats, "")
-	p.flagSet.IntVar(avoidRepeats, "avoid-repeats", DefaultAvoidRepeats, "")
+	return options
+}
 
-	qrOutput := p.flagSet.Bool("q", false, "")
-	p.flagSet.BoolVar(qrOutput, "qr", false, "")
+func (p *CommandLineParser) Parse(args []string) (*CommandLineOptions, error) {
+	options := newCommandLineOptions(p.command)
+	defineFlags(p.flagSet, commandFlags(p.command, options))
 
 	p.flagSet.Usage = p.printUsage
 
@@ -68,33 +200,121 @@ func (p *CommandLineParser) Parse(args []string) (*CommandLineOptions, error) {
 		return nil, err
 	}
 
-	options := &CommandLineOptions{
-		length:       *length,
-		lowercase:    *lowercase,
-		uppercase:    *uppercase,
-		numbers:      *numbers,
-		symbols:      *symbols,
-		custom:       *custom,
-		avoidRepeats: *avoidRepeats,
-		qrOutput:     *qrOutput,
+	if p.flagSet.NArg() > 0 {
+		return nil, fmt.Errorf("%s: %w", p.flagSet.Arg(0), ErrUnknownCommand)
+	}
+
+	options.environ = p.environ
+	if p.environ != nil {
+		if options.environment, err = applyEnvironment(p.flagSet, p.environ); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.config != nil {
+		if err := p.config.apply(p.flagSet, options.profile); err != nil {
+			return nil, err
+		}
+	} else if options.profile != "" {
+		return nil, fmt.Errorf("%s: %w", options.profile, ErrUnknownProfile)
+	}
+
+	if p.command == PhraseCommand && options.words <= 0 {
+		return nil, passgen.ErrWordsMustBeGreaterThanZero
+	}
+
+	// A length given by the user is not silently clamped by the policy.
+	if f := p.flagSet.Lookup("length"); f != nil {
+		options.lengthSet = visitedFlagValues(p.flagSet)[f.Value]
+	}
+
+	if options.policyPath != "" {
+		if options.policy, err = LoadPolicy(options.policyPath); err != nil {
+			return nil, err
+		}
+	}
+
+	if options.insecureSeed != "" {
+		options.random = passgen.NewSeededRandomSource(options.insecureSeed)
 	}
 
 	return options, nil
 }
 
-func InitializeCommandLine() (*CommandLineOptions, error) {
-	parser := NewCommandLineParser()
+// isGenerateFlag reports whether name is one of the generate command flags.
+func isGenerateFlag(name string) bool {
+	for _, f := range generateFlags(newCommandLineOptions(GenerateCommand)) {
+		if f.value != nil && slices.Contains(f.names(), name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// lookupOptionFlag returns the flag of an option set by the environment or the
+// configuration file, or nil if the option is skipped: flags given on the
+// command line take precedence, also over their short or long twin, and the
+// phrase command does not know the password options. known is false for
+// unknown options and for the seed, which would silently make every password
+// predictable.
+func lookupOptionFlag(flagSet *flag.FlagSet, name string) (f *flag.Flag, known bool) {
+	f = flagSet.Lookup(name)
+	if name == "insecure-seed" || (f == nil && !isGenerateFlag(name)) {
+		return nil, false
+	}
+
+	if f == nil || visitedFlagValues(flagSet)[f.Value] {
+		return nil, true
+	}
 
-	args := os.Args[1:]
+	return f, true
+}
+
+// loadCommandLine parses and validates the options of the generate or phrase
+// command, filling in defaults from environ, formatted like os.Environ, and the
+// configuration file.
+func loadCommandLine(command string, args, environ []string, stderr io.Writer) (*CommandLineOptions, error) {
+	var config *Config
+
+	configPath, err := ConfigPath(environ)
+	if err == nil {
+		if config, err = LoadConfig(configPath); err != nil {
+			// Help must still work with a broken configuration file.
+			var help bytes.Buffer
+			if _, parseErr := newCommandLineParser(command).WithEnvironment(environ).WithOutput(&help).Parse(args); parseErr == flag.ErrHelp {
+				help.WriteTo(stderr)
+				return nil, parseErr
+			}
+
+			return nil, err
+		}
+	}
 
-	options, err := parser.Parse(args)
-	if err == flag.ErrHelp {
-		os.Exit(0)
-	} else if err != nil {
+	parse := func(environ []string, stderr io.Writer) (*CommandLineOptions, error) {
+		parser := newCommandLineParser(command).WithEnvironment(environ).WithOutput(stderr)
+		if config != nil {
+			parser.WithConfig(config)
+		}
+
+		return parser.Parse(args)
+	}
+
+	options, err := parse(environ, stderr)
+	if err != nil {
 		return nil, err
 	}
 
 	if err := options.Validate(); err != nil {
+		// Name the variable when the options are valid without it, an invalid
+		// value in the environment is easy to miss.
+		for _, name := range options.environment {
+			retry, retryErr := parse(withoutVariable(environ, name), io.Discard)
+			if retryErr == nil && retry.Validate() == nil {
+				return nil, fmt.Errorf("%s: %w", name, err)
+			}
+		}
+
 		return nil, err
 	}
 
@@ -102,28 +322,59 @@ func InitializeCommandLine() (*CommandLineOptions, error) {
 }
 
 func (p *CommandLineParser) printUsage() {
-	fmt.Fprintf(os.Stderr, "Usage: %s [options]\n\n", filepath.Base(os.Args[0]))
-	fmt.Fprintf(os.Stderr, "A secure password generator with customizable options.\n\n")
-	fmt.Fprintf(os.Stderr, "Options:\n")
-
-	fmt.Fprintf(os.Stderr, "  -h, --help\t\t\t\tDisplay the help message.\n")
-	fmt.Fprintf(os.Stderr, "  -l, --length <length>\t\t\tPassword length (default: 12)\n")
-	fmt.Fprintf(os.Stderr, "  -L, --lowercase\t\t\tInclude lowercase letters (a-z) (default: true)\n")
-	fmt.Fprintf(os.Stderr, "  -U, --uppercase\t\t\tInclude uppercase letters (A-Z) (default: true)\n")
-	fmt.Fprintf(os.Stderr, "  -N, --numbers\t\t\t\tInclude numbers (0-9) (default: true)\n")
-	fmt.Fprintf(os.Stderr, "  -S, --symbols\t\t\t\tInclude symbols (!@#$%%^&* etc.)\n")
-	fmt.Fprintf(os.Stderr, "  -C, --custom <custom>\t\t\tCustom character set to use\n")
-	fmt.Fprintf(os.Stderr, "  -a, --avoid-repeats <avoid-repeats>\tNumber of last characters that shouldn't repeat (default: 1)\n")
-	fmt.Fprintf(os.Stderr, "  -q, --qr\t\t\t\tGenerate QR code output in ANSI UTF-8 format\n")
-	fmt.Fprintf(os.Stderr, "  -v, --version\t\t\t\tGet version\n")
-
-	fmt.Fprintf(os.Stderr, "\nExamples:\n")
-	fmt.Fprintf(os.Stderr, "  %s -l 16 --uppercase --numbers --symbols \n", filepath.Base(os.Args[0]))
-	fmt.Fprintf(os.Stderr, "  %s --length 12 --uppercase --numbers\n", filepath.Base(os.Args[0]))
-	fmt.Fprintf(os.Stderr, "  %s -l 20 --custom \"abcdef123456!@#\"\n", filepath.Base(os.Args[0]))
-}
-
-func PrintVersion(version, commit, date string) {
+	w := p.flagSet.Output()
+
+	if p.command == PhraseCommand {
+		fmt.Fprintf(w, "Usage: %s %s [options]\n\n", ProgramName, PhraseCommand)
+		fmt.Fprintf(w, "Generate passphrases from the EFF large wordlist.\n\n")
+	} else {
+		fmt.Fprintf(w, "Usage: %s [%s] [options]\n\n", ProgramName, GenerateCommand)
+		fmt.Fprintf(w, "A secure password generator with customizable options.\n\n")
+	}
+
+	fmt.Fprintf(w, "Options:\n")
+	printFlags(w, commandFlags(p.command, newCommandLineOptions(p.command)))
+
+	fmt.Fprintf(w, "\nExamples:\n")
+	for _, example := range commandExamples(p.command) {
+		fmt.Fprintf(w, "  %s\n", example)
+	}
+
+	fmt.Fprintf(w, "\nOptions can also be set as %s<OPTION> environment variables, e.g. %s=16.\n", EnvironmentPrefix, EnvironmentVariable("length"))
+	if configPath, err := ConfigPath(p.environ); err == nil {
+		fmt.Fprintf(w, "Defaults and profiles are read from %s.\n", configPath)
+	}
+}
+
+func commandExamples(command string) []string {
+	if command == PhraseCommand {
+		return []string{
+			ProgramName + " " + PhraseCommand,
+			ProgramName + " " + PhraseCommand + " -w 5 --capitalize --with-number",
+			ProgramName + " " + PhraseCommand + " --separator \" \" -n 3",
+		}
+	}
+
+	return []string{
+		ProgramName + " -l 16 --uppercase --numbers --symbols",
+		ProgramName + " --length 12 --uppercase --numbers",
+		ProgramName + " -l 16 -S --min-numbers 2 --min-symbols 2",
+		ProgramName + " -l 20 --custom \"abcdef123456!@#\"",
+		ProgramName + " --words 6 --capitalize --with-number",
+		ProgramName + " --mode pronounceable -l 10 --digits 2 --capitalize",
+		ProgramName + " --mode totp --totp-issuer ACME --totp-account deploy -q",
+		ProgramName + " -n 100 --unique -l 16",
+		ProgramName + " -l 24 -S --format json",
+		ProgramName + " -l 16 -S --qr-png password.png --qr-size 512",
+		ProgramName + " -l 20 -q --wifi-ssid \"Guest\"",
+		ProgramName + " -l 24 -S --clip --clip-timeout 30s",
+		ProgramName + " -l 10 --exclude-ambiguous --exclude \"oO\"",
+		ProgramName + " --profile aws",
+		ProgramName + " --policy legacy-erp.json",
+	}
+}
+
+func PrintVersion(w io.Writer, version, commit, date string) {
 	shortCommitHash := commit
 	buildDate := date
 
@@ -137,30 +388,336 @@ func PrintVersion(version, commit, date string) {
 		buildDate = dateTime.Format(time.DateOnly)
 	}
 
-	fmt.Printf("passgen version %s (%s) released at %s\n", version, shortCommitHash, buildDate)
+	fmt.Fprintf(w, "passgen version %s (%s) released at %s\n", version, shortCommitHash, buildDate)
+}
+
+// ToPasswordGeneratorOptions returns the password options with the policy
+// applied, as the generator uses them.
+func (c *CommandLineOptions) ToPasswordGeneratorOptions() *passgen.PasswordGeneratorOptions {
+	options := c.passwordGeneratorOptions()
+	if c.policy != nil {
+		c.policy.Apply(options)
+	}
+
+	return options
 }
 
-func (c *CommandLineOptions) ToPasswordGeneratorOptions() *PasswordGeneratorOptions {
-	return &PasswordGeneratorOptions{
-		Length:       c.length,
-		Lowercase:    c.lowercase,
-		Uppercase:    c.uppercase,
-		Numbers:      c.numbers,
-		Symbols:      c.symbols,
-		Custom:       c.custom,
-		AvoidRepeats: c.avoidRepeats,
-		QrCode:       c.qrOutput,
+// passwordGeneratorOptions returns the password options before the policy is
+// applied.
+func (c *CommandLineOptions) passwordGeneratorOptions() *passgen.PasswordGeneratorOptions {
+	return &passgen.PasswordGeneratorOptions{
+		Length:           c.length,
+		Lowercase:        c.lowercase,
+		Uppercase:        c.uppercase,
+		Numbers:          c.numbers,
+		Symbols:          c.symbols,
+		Custom:           c.custom,
+		AvoidRepeats:     c.avoidRepeats,
+		MinLowercase:     c.minLowercase,
+		MinUppercase:     c.minUppercase,
+		MinNumbers:       c.minNumbers,
+		MinSymbols:       c.minSymbols,
+		MinCustom:        c.minCustom,
+		ExcludeAmbiguous: c.excludeAmbiguous,
+		Exclude:          c.exclude,
+		Random:           c.random,
 	}
 }
 
 func (c *CommandLineOptions) Validate() error {
 	if c.length <= 0 {
-		return ErrLengthMustBeGreaterThanZero
+		return passgen.ErrLengthMustBeGreaterThanZero
 	}
 
 	if c.avoidRepeats < 0 {
-		return ErrAvoidRepeatsMustBeEqualOrGreaterThanZero
+		return passgen.ErrAvoidRepeatsMustBeEqualOrGreaterThanZero
+	}
+
+	if c.words < 0 {
+		return passgen.ErrWordsMustBeGreaterThanZero
+	}
+
+	if c.count <= 0 {
+		return passgen.ErrCountMustBeGreaterThanZero
+	}
+
+	if c.format != FormatText && c.format != FormatJSON && c.format != FormatCSV {
+		return ErrUnknownOutputFormat
+	}
+
+	if c.mode != ModeRandom && c.mode != ModePronounceable && c.mode != ModeTotp {
+		return ErrUnknownMode
+	}
+
+	if c.IsPassphrase() && c.mode != ModeRandom {
+		return ErrWordsWithMode
+	}
+
+	if c.IsPronounceable() {
+		if _, err := c.ToPronounceableGeneratorOptions().Validate(); err != nil {
+			return err
+		}
+	}
+
+	if c.policy != nil && (c.IsPassphrase() || c.mode != ModeRandom) {
+		return ErrPolicyWithMode
+	}
+
+	if c.policy != nil && c.lengthSet {
+		if err := c.policy.CheckLength(c.length); err != nil {
+			return err
+		}
+	}
+
+	if c.IsTotp() {
+		if _, err := c.ToTotpGeneratorOptions().Validate(); err != nil {
+			return err
+		}
+
+		if strings.TrimSpace(c.totpAccount) == "" {
+			return ErrTotpWithoutAccount
+		}
+	}
+
+	if c.qrSize <= 0 {
+		return passgen.ErrQrSizeMustBeGreaterThanZero
+	}
+
+	if _, err := passgen.ParseQrLevel(c.qrLevel); err != nil {
+		return err
+	}
+
+	if c.qrMargin < 0 {
+		return passgen.ErrQrMarginMustBeEqualOrGreaterThanZero
+	}
+
+	if _, err := passgen.ParseQrStyle(c.qrStyle); err != nil {
+		return err
+	}
+
+	if (c.qrPng != "" || c.qrSvg != "") && c.count != 1 {
+		return ErrQrFileWithCount
+	}
+
+	if c.clip {
+		if c.count != 1 {
+			return ErrClipWithCount
+		}
+
+		if c.format != FormatText {
+			return ErrClipWithFormat
+		}
+
+		if c.qrOutput {
+			return ErrClipWithQr
+		}
+	}
+
+	if !isClipboardBackend(c.clipBackend) {
+		return ErrUnknownClipboard
+	}
+
+	if c.clipTimeout < 0 {
+		return ErrClipTimeoutMustBeEqualOrGreaterThanZero
+	}
+
+	if c.IsWifi() {
+		if c.IsTotp() {
+			return ErrWifiWithTotp
+		}
+
+		if !c.qrOutput && c.qrPng == "" && c.qrSvg == "" {
+			return ErrWifiWithoutQr
+		}
+
+		security := c.ToWifiCredentials("").Security
+		if security != passgen.WifiSecurityWPA && security != passgen.WifiSecurityWEP && security != passgen.WifiSecuritySAE {
+			return passgen.ErrUnknownWifiSecurity
+		}
+
+		if err := c.checkWifiPasswordLength(security); err != nil {
+			return err
+		}
+	}
+
+	if _, err := c.ToPasswordGeneratorOptions().Validate(); err != nil {
+		return err
 	}
 
 	return nil
 }
+
+func (c *CommandLineOptions) ToPassphraseGeneratorOptions() *passgen.PassphraseGeneratorOptions {
+	return &passgen.PassphraseGeneratorOptions{
+		Words:      c.words,
+		Separator:  c.separator,
+		Capitalize: c.capitalize,
+		Number:     c.withNumber,
+		Symbol:     c.withSymbol,
+		Random:     c.random,
+	}
+}
+
+func (c *CommandLineOptions) ToPronounceableGeneratorOptions() *passgen.PronounceableGeneratorOptions {
+	return &passgen.PronounceableGeneratorOptions{
+		Length:     c.length,
+		Capitalize: c.capitalize,
+		Digits:     c.digits,
+		Random:     c.random,
+	}
+}
+
+func (c *CommandLineOptions) ToTotpGeneratorOptions() *passgen.TotpGeneratorOptions {
+	return &passgen.TotpGeneratorOptions{
+		Bytes:     c.totpBytes,
+		Algorithm: strings.ToUpper(c.totpAlgorithm),
+		Digits:    c.totpDigits,
+		Period:    c.totpPeriod,
+		Issuer:    c.totpIssuer,
+		Account:   c.totpAccount,
+		Random:    c.random,
+	}
+}
+
+func (c *CommandLineOptions) ToWifiCredentials(password string) *passgen.WifiCredentials {
+	return &passgen.WifiCredentials{
+		SSID:     c.wifiSsid,
+		Password: password,
+		Security: strings.ToUpper(c.wifiSecurity),
+		Hidden:   c.wifiHidden,
+	}
+}
+
+func (c *CommandLineOptions) IsWifi() bool {
+	return c.wifiSsid != ""
+}
+
+func (c *CommandLineOptions) IsPassphrase() bool {
+	return c.words > 0
+}
+
+func (c *CommandLineOptions) IsPronounceable() bool {
+	return c.mode == ModePronounceable
+}
+
+func (c *CommandLineOptions) IsTotp() bool {
+	return c.mode == ModeTotp
+}
+
+// checkWifiPasswordLength checks that every password the options can generate
+// has a valid length in bytes for the Wi-Fi security type.
+func (c *CommandLineOptions) checkWifiPasswordLength(security string) error {
+	var shortest, longest int
+	switch {
+	case c.IsPassphrase():
+		shortest, longest = passgen.PassphraseByteLengthRange(*c.ToPassphraseGeneratorOptions())
+	case c.IsPronounceable():
+		shortest, longest = c.length, c.length
+	default:
+		shortest, longest = passgen.PasswordByteLengthRange(*c.ToPasswordGeneratorOptions())
+	}
+
+	if err := passgen.CheckWifiPasswordLength(security, shortest); err != nil {
+		return err
+	}
+
+	return passgen.CheckWifiPasswordLength(security, longest)
+}
+
+func (c *CommandLineOptions) Generate() ([]string, error) {
+	if c.IsPassphrase() {
+		options := *c.ToPassphraseGeneratorOptions()
+		return c.generateBatch(passgen.CalculatePassphraseEntropy(options), func() (string, error) {
+			return passgen.GeneratePassphrase(options)
+		})
+	}
+
+	if c.IsPronounceable() {
+		options := *c.ToPronounceableGeneratorOptions()
+		return c.generateBatch(passgen.CalculatePronounceableEntropy(options), func() (string, error) {
+			return passgen.GeneratePronounceable(options)
+		})
+	}
+
+	if c.IsTotp() {
+		options := *c.ToTotpGeneratorOptions()
+		return c.generateBatch(passgen.CalculateTotpEntropy(options), func() (string, error) {
+			return passgen.GenerateTotpSecret(options)
+		})
+	}
+
+	if c.policy != nil {
+		return passgen.GeneratePolicyPasswords(*c.passwordGeneratorOptions(), c.policy, c.count, c.unique)
+	}
+
+	if c.unique {
+		return passgen.GenerateUniquePasswords(*c.ToPasswordGeneratorOptions(), c.count)
+	}
+
+	return passgen.GeneratePasswords(*c.ToPasswordGeneratorOptions(), c.count)
+}
+
+func (c *CommandLineOptions) generateBatch(entropy passgen.Entropy, generate func() (string, error)) ([]string, error) {
+	if c.unique && entropy.Bits < math.Log2(float64(c.count)) {
+		return nil, passgen.ErrNotEnoughUniquePasswords
+	}
+
+	return passgen.GenerateBatch(c.count, c.unique, generate)
+}
+
+func (c *CommandLineOptions) Clip() bool {
+	return c.clip
+}
+
+func (c *CommandLineOptions) ClipBackend() string {
+	return c.clipBackend
+}
+
+func (c *CommandLineOptions) ShowEntropy() bool {
+	return c.showEntropy
+}
+
+func (c *CommandLineOptions) Entropy() (passgen.Entropy, error) {
+	if c.IsPassphrase() {
+		return passgen.CalculatePassphraseEntropy(*c.ToPassphraseGeneratorOptions()), nil
+	}
+
+	if c.IsPronounceable() {
+		return passgen.CalculatePronounceableEntropy(*c.ToPronounceableGeneratorOptions()), nil
+	}
+
+	if c.IsTotp() {
+		return passgen.CalculateTotpEntropy(*c.ToTotpGeneratorOptions()), nil
+	}
+
+	return passgen.CalculatePasswordEntropy(*c.ToPasswordGeneratorOptions())
+}
+
+func (c *CommandLineOptions) Warnings() []string {
+	var warnings []string
+
+	if c.random != nil && !c.random.Secure() {
+		warnings = append(warnings, "Warning: --insecure-seed makes the output predictable. Never use it for real secrets.")
+	}
+
+	if c.IsPassphrase() || c.IsPronounceable() || c.IsTotp() {
+		return warnings
+	}
+
+	charset := passgen.NewCharsetBuilderFromPasswordGeneratorOptions(*c.ToPasswordGeneratorOptions())
+	if overlaps := charset.Overlaps(); overlaps != "" {
+		warnings = append(warnings, fmt.Sprintf(
+			"Warning: characters %q appear in more than one character set and are used only once (effective pool size: %d).",
+			overlaps, charset.Length(),
+		))
+	}
+
+	if repeats := charset.Repeats(); repeats != "" {
+		warnings = append(warnings, fmt.Sprintf(
+			"Warning: characters %q are repeated within a character set and are used only once (effective pool size: %d).",
+			repeats, charset.Length(),
+		))
+	}
+
+	return warnings
+}
@@ -3,43 +3,17 @@ package internal
 import (
 	"bytes"
 	"io"
-	"os"
+	"math"
+	"slices"
+	"strings"
 	"testing"
+	"unicode"
 
+	"github.com/amirhossein-fzl/passgen/pkg/passgen"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
-func captureStderr(fn func()) string {
-	oldStderr := os.Stderr
-	r, w, _ := os.Pipe()
-	os.Stderr = w
-
-	fn()
-
-	w.Close()
-	os.Stderr = oldStderr
-
-	var buf bytes.Buffer
-	io.Copy(&buf, r)
-	return buf.String()
-}
-
-func captureStdout(fn func()) string {
-	oldStdout := os.Stdout
-	r, w, _ := os.Pipe()
-	os.Stdout = w
-
-	fn()
-
-	w.Close()
-	os.Stdout = oldStdout
-
-	var buf bytes.Buffer
-	io.Copy(&buf, r)
-	return buf.String()
-}
-
 func TestNewCommandLineParser(t *testing.T) {
 	parser := NewCommandLineParser()
 
@@ -64,6 +38,43 @@ func TestCommandLineParserParseDefaultValues(t *testing.T) {
 	assert.Empty(t, options.custom)
 	assert.Equal(t, DefaultAvoidRepeats, options.avoidRepeats)
 	assert.False(t, options.qrOutput)
+	assert.Zero(t, options.words)
+	assert.Equal(t, DefaultWordSeparator, options.separator)
+	assert.False(t, options.IsPassphrase())
+	assert.Equal(t, DefaultCount, options.count)
+	assert.False(t, options.unique)
+	assert.Equal(t, FormatText, options.format)
+	assert.Equal(t, ModeRandom, options.mode)
+	assert.False(t, options.IsPronounceable())
+	assert.Empty(t, options.qrPng)
+	assert.Empty(t, options.qrSvg)
+	assert.Equal(t, DefaultQrSize, options.qrSize)
+	assert.Equal(t, DefaultQrLevel, options.qrLevel)
+	assert.Equal(t, DefaultQrMargin, options.qrMargin)
+	assert.Equal(t, DefaultQrStyle, options.qrStyle)
+	assert.Empty(t, options.wifiSsid)
+	assert.Equal(t, DefaultWifiSecurity, options.wifiSecurity)
+	assert.False(t, options.wifiHidden)
+	assert.False(t, options.IsWifi())
+	assert.Equal(t, DefaultTotpBytes, options.totpBytes)
+	assert.Equal(t, DefaultTotpAlgorithm, options.totpAlgorithm)
+	assert.Equal(t, DefaultTotpDigits, options.totpDigits)
+	assert.Equal(t, DefaultTotpPeriod, options.totpPeriod)
+	assert.False(t, options.IsTotp())
+	assert.False(t, options.Clip())
+	assert.Equal(t, DefaultClipBackend, options.ClipBackend())
+	assert.Equal(t, DefaultClipTimeout, options.clipTimeout)
+}
+
+// newTestCommandLineOptions returns the defaults of the generate command with
+// the changes of set applied.
+func newTestCommandLineOptions(set func(o *CommandLineOptions)) *CommandLineOptions {
+	options := newCommandLineOptions(GenerateCommand)
+	if set != nil {
+		set(options)
+	}
+
+	return options
 }
 
 func TestCommandLineParserParseShortFlags(t *testing.T) {
@@ -75,58 +86,35 @@ func TestCommandLineParserParseShortFlags(t *testing.T) {
 		{
 			name: "length flag",
 			args: []string{"-l", "20"},
-			expected: &CommandLineOptions{
-				length:       20,
-				lowercase:    true,
-				uppercase:    true,
-				numbers:      true,
-				symbols:      false,
-				custom:       "",
-				avoidRepeats: DefaultAvoidRepeats,
-				qrOutput:     false,
-			},
+			expected: newTestCommandLineOptions(func(o *CommandLineOptions) {
+				o.length = 20
+				o.lengthSet = true
+			}),
 		},
 		{
 			name: "boolean flags true",
 			args: []string{"-S=true", "-q=true"},
-			expected: &CommandLineOptions{
-				length:       DefaultPasswordLength,
-				lowercase:    true,
-				uppercase:    true,
-				numbers:      true,
-				symbols:      true,
-				custom:       "",
-				avoidRepeats: DefaultAvoidRepeats,
-				qrOutput:     true,
-			},
+			expected: newTestCommandLineOptions(func(o *CommandLineOptions) {
+				o.symbols = true
+				o.qrOutput = true
+			}),
 		},
 		{
 			name: "boolean flags false",
 			args: []string{"-L=false", "-U=false", "-N=false"},
-			expected: &CommandLineOptions{
-				length:       DefaultPasswordLength,
-				lowercase:    false,
-				uppercase:    false,
-				numbers:      false,
-				symbols:      false,
-				custom:       "",
-				avoidRepeats: DefaultAvoidRepeats,
-				qrOutput:     false,
-			},
+			expected: newTestCommandLineOptions(func(o *CommandLineOptions) {
+				o.lowercase = false
+				o.uppercase = false
+				o.numbers = false
+			}),
 		},
 		{
 			name: "custom and avoid repeats",
 			args: []string{"-C", "abc123", "-a", "3"},
-			expected: &CommandLineOptions{
-				length:       DefaultPasswordLength,
-				lowercase:    true,
-				uppercase:    true,
-				numbers:      true,
-				symbols:      false,
-				custom:       "abc123",
-				avoidRepeats: 3,
-				qrOutput:     false,
-			},
+			expected: newTestCommandLineOptions(func(o *CommandLineOptions) {
+				o.custom = "abc123"
+				o.avoidRepeats = 3
+			}),
 		},
 	}
 
@@ -150,58 +138,35 @@ func TestCommandLineParserParseLongFlags(t *testing.T) {
 		{
 			name: "length flag",
 			args: []string{"--length", "25"},
-			expected: &CommandLineOptions{
-				length:       25,
-				lowercase:    true,
-				uppercase:    true,
-				numbers:      true,
-				symbols:      false,
-				custom:       "",
-				avoidRepeats: DefaultAvoidRepeats,
-				qrOutput:     false,
-			},
+			expected: newTestCommandLineOptions(func(o *CommandLineOptions) {
+				o.length = 25
+				o.lengthSet = true
+			}),
 		},
 		{
 			name: "all boolean flags",
 			args: []string{"--lowercase=false", "--uppercase=false", "--numbers=false", "--symbols=true", "--qr=true"},
-			expected: &CommandLineOptions{
-				length:       DefaultPasswordLength,
-				lowercase:    false,
-				uppercase:    false,
-				numbers:      false,
-				symbols:      true,
-				custom:       "",
-				avoidRepeats: DefaultAvoidRepeats,
-				qrOutput:     true,
-			},
+			expected: newTestCommandLineOptions(func(o *CommandLineOptions) {
+				o.lowercase = false
+				o.uppercase = false
+				o.numbers = false
+				o.symbols = true
+				o.qrOutput = true
+			}),
 		},
 		{
 			name: "custom character set",
 			args: []string{"--custom", "!@#$%^&*()"},
-			expected: &CommandLineOptions{
-				length:       DefaultPasswordLength,
-				lowercase:    true,
-				uppercase:    true,
-				numbers:      true,
-				symbols:      false,
-				custom:       "!@#$%^&*()",
-				avoidRepeats: DefaultAvoidRepeats,
-				qrOutput:     false,
-			},
+			expected: newTestCommandLineOptions(func(o *CommandLineOptions) {
+				o.custom = "!@#$%^&*()"
+			}),
 		},
 		{
 			name: "avoid repeats",
 			args: []string{"--avoid-repeats", "5"},
-			expected: &CommandLineOptions{
-				length:       DefaultPasswordLength,
-				lowercase:    true,
-				uppercase:    true,
-				numbers:      true,
-				symbols:      false,
-				custom:       "",
-				avoidRepeats: 5,
-				qrOutput:     false,
-			},
+			expected: newTestCommandLineOptions(func(o *CommandLineOptions) {
+				o.avoidRepeats = 5
+			}),
 		},
 	}
 
@@ -313,6 +278,229 @@ func TestCommandLineParserParseEdgeCases(t *testing.T) {
 	}
 }
 
+func TestCommandLineParserParseMinimumFlags(t *testing.T) {
+	parser := NewCommandLineParser()
+
+	args := []string{
+		"--min-lowercase", "1",
+		"--min-uppercase", "2",
+		"--min-numbers", "3",
+		"--min-symbols", "4",
+		"--min-custom", "5",
+	}
+
+	options, err := parser.Parse(args)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, options.minLowercase)
+	assert.Equal(t, 2, options.minUppercase)
+	assert.Equal(t, 3, options.minNumbers)
+	assert.Equal(t, 4, options.minSymbols)
+	assert.Equal(t, 5, options.minCustom)
+
+	genOptions := options.ToPasswordGeneratorOptions()
+	assert.Equal(t, 1, genOptions.MinLowercase)
+	assert.Equal(t, 2, genOptions.MinUppercase)
+	assert.Equal(t, 3, genOptions.MinNumbers)
+	assert.Equal(t, 4, genOptions.MinSymbols)
+	assert.Equal(t, 5, genOptions.MinCustom)
+}
+
+func TestCommandLineParserParseExcludeFlags(t *testing.T) {
+	parser := NewCommandLineParser()
+
+	options, err := parser.Parse([]string{"--exclude-ambiguous", "--exclude", "xyz"})
+
+	require.NoError(t, err)
+	assert.True(t, options.excludeAmbiguous)
+	assert.Equal(t, "xyz", options.exclude)
+
+	genOptions := options.ToPasswordGeneratorOptions()
+	assert.True(t, genOptions.ExcludeAmbiguous)
+	assert.Equal(t, "xyz", genOptions.Exclude)
+}
+
+func TestCommandLineParserParsePassphraseFlags(t *testing.T) {
+	parser := NewCommandLineParser()
+
+	options, err := parser.Parse([]string{"-w", "5", "--separator", " ", "--capitalize", "--with-number", "--with-symbol"})
+
+	require.NoError(t, err)
+	assert.True(t, options.IsPassphrase())
+	assert.Equal(t, &passgen.PassphraseGeneratorOptions{
+		Words:      5,
+		Separator:  " ",
+		Capitalize: true,
+		Number:     true,
+		Symbol:     true,
+	}, options.ToPassphraseGeneratorOptions())
+}
+
+func TestCommandLineOptionsGenerate(t *testing.T) {
+	t.Run("generates password by default", func(t *testing.T) {
+		options := &CommandLineOptions{length: 16, lowercase: true, count: 1}
+
+		passwords, err := options.Generate()
+
+		require.NoError(t, err)
+		require.Len(t, passwords, 1)
+		assert.Len(t, passwords[0], 16)
+	})
+
+	t.Run("generates passphrase with words", func(t *testing.T) {
+		options := &CommandLineOptions{length: 16, words: 4, separator: "_", count: 1}
+
+		passphrases, err := options.Generate()
+
+		require.NoError(t, err)
+		require.Len(t, passphrases, 1)
+		assert.Len(t, strings.Split(passphrases[0], "_"), 4)
+	})
+
+	t.Run("generates pronounceable passwords", func(t *testing.T) {
+		options := &CommandLineOptions{length: 10, mode: ModePronounceable, digits: 2, count: 3}
+
+		passwords, err := options.Generate()
+
+		require.NoError(t, err)
+		require.Len(t, passwords, 3)
+		for _, password := range passwords {
+			assert.Len(t, password, 10)
+			digits := 0
+			for _, character := range password {
+				if unicode.IsDigit(character) {
+					digits++
+				}
+			}
+			assert.Equal(t, 2, digits)
+		}
+	})
+
+	t.Run("generates TOTP secrets", func(t *testing.T) {
+		options := &CommandLineOptions{mode: ModeTotp, count: 2, totpBytes: 20, totpAlgorithm: "sha256", totpDigits: 6, totpPeriod: 30}
+
+		secrets, err := options.Generate()
+
+		require.NoError(t, err)
+		require.Len(t, secrets, 2)
+		for _, secret := range secrets {
+			assert.Regexp(t, "^[A-Z2-7]{32}$", secret)
+		}
+	})
+
+	t.Run("generates batch of unique passwords", func(t *testing.T) {
+		options := &CommandLineOptions{length: 3, numbers: true, count: 200, unique: true}
+
+		passwords, err := options.Generate()
+
+		require.NoError(t, err)
+		assert.Len(t, passwords, 200)
+		seen := make(map[string]bool)
+		for _, password := range passwords {
+			assert.False(t, seen[password], password)
+			seen[password] = true
+		}
+	})
+
+	t.Run("generates batch of unique passphrases", func(t *testing.T) {
+		options := &CommandLineOptions{words: 1, count: 50, unique: true}
+
+		passphrases, err := options.Generate()
+
+		require.NoError(t, err)
+		assert.Len(t, passphrases, 50)
+		assert.Len(t, slices.Compact(slices.Sorted(slices.Values(passphrases))), 50)
+	})
+
+	t.Run("rejects more unique passphrases than possible", func(t *testing.T) {
+		options := &CommandLineOptions{words: 1, count: 7777, unique: true}
+
+		passphrases, err := options.Generate()
+
+		assert.Nil(t, passphrases)
+		assert.Equal(t, passgen.ErrNotEnoughUniquePasswords, err)
+	})
+}
+
+func TestCommandLineOptionsInsecureSeed(t *testing.T) {
+	generate := func(args ...string) []string {
+		options, err := NewCommandLineParser().Parse(args)
+		require.NoError(t, err)
+		require.NoError(t, options.Validate())
+
+		passwords, err := options.Generate()
+		require.NoError(t, err)
+
+		return passwords
+	}
+
+	t.Run("same seed generates the same output", func(t *testing.T) {
+		for _, mode := range []string{ModeRandom, ModePronounceable, ModeTotp} {
+			args := []string{"--insecure-seed", "debug", "-m", mode, "-n", "3", "--totp-account", "ci"}
+
+			assert.Equal(t, generate(args...), generate(args...), mode)
+		}
+
+		args := []string{"--insecure-seed", "debug", "-w", "5"}
+		assert.Equal(t, generate(args...), generate(args...))
+	})
+
+	t.Run("different seeds generate different output", func(t *testing.T) {
+		assert.NotEqual(t, generate("--insecure-seed", "one"), generate("--insecure-seed", "two"))
+	})
+
+	t.Run("warns about the seed", func(t *testing.T) {
+		options, err := NewCommandLineParser().Parse([]string{"--insecure-seed", "debug"})
+		require.NoError(t, err)
+
+		warnings := options.Warnings()
+
+		require.Len(t, warnings, 1)
+		assert.Contains(t, warnings[0], "--insecure-seed")
+	})
+}
+
+func TestCommandLineOptionsEntropy(t *testing.T) {
+	t.Run("password entropy", func(t *testing.T) {
+		parser := NewCommandLineParser()
+		options, err := parser.Parse([]string{"-l", "10", "-U=false", "-N=false", "-a", "0", "--show-entropy"})
+		require.NoError(t, err)
+
+		entropy, err := options.Entropy()
+
+		require.NoError(t, err)
+		assert.True(t, options.ShowEntropy())
+		assert.InDelta(t, 10*math.Log2(26), entropy.Bits, 1e-9)
+	})
+
+	t.Run("pronounceable entropy", func(t *testing.T) {
+		options := &CommandLineOptions{length: 12, mode: ModePronounceable}
+
+		entropy, err := options.Entropy()
+
+		require.NoError(t, err)
+		assert.Equal(t, passgen.CalculatePronounceableEntropy(passgen.PronounceableGeneratorOptions{Length: 12}), entropy)
+	})
+
+	t.Run("TOTP entropy", func(t *testing.T) {
+		options := &CommandLineOptions{mode: ModeTotp, totpBytes: 32}
+
+		entropy, err := options.Entropy()
+
+		require.NoError(t, err)
+		assert.Equal(t, 256.0, entropy.Bits)
+	})
+
+	t.Run("passphrase entropy", func(t *testing.T) {
+		options := &CommandLineOptions{words: 5}
+
+		entropy, err := options.Entropy()
+
+		require.NoError(t, err)
+		assert.InDelta(t, 5*math.Log2(7776), entropy.Bits, 1e-9)
+	})
+}
+
 func TestCommandLineOptionsValidate(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -320,60 +508,252 @@ func TestCommandLineOptionsValidate(t *testing.T) {
 		expectedErr error
 	}{
 		{
-			name: "valid options - default values",
-			options: &CommandLineOptions{
-				length:       DefaultPasswordLength,
-				avoidRepeats: DefaultAvoidRepeats,
-			},
+			name:        "valid options - default values",
+			options:     newTestCommandLineOptions(nil),
 			expectedErr: nil,
 		},
 		{
 			name: "valid options - custom values",
-			options: &CommandLineOptions{
-				length:       20,
-				avoidRepeats: 5,
-			},
+			options: newTestCommandLineOptions(func(o *CommandLineOptions) {
+				o.length = 20
+				o.avoidRepeats = 5
+			}),
 			expectedErr: nil,
 		},
 		{
 			name: "valid options - minimum values",
-			options: &CommandLineOptions{
-				length:       1,
-				avoidRepeats: 0,
-			},
+			options: newTestCommandLineOptions(func(o *CommandLineOptions) {
+				o.length = 1
+				o.avoidRepeats = 0
+			}),
 			expectedErr: nil,
 		},
 		{
 			name: "invalid length - zero",
-			options: &CommandLineOptions{
-				length:       0,
-				avoidRepeats: DefaultAvoidRepeats,
-			},
-			expectedErr: ErrLengthMustBeGreaterThanZero,
+			options: newTestCommandLineOptions(func(o *CommandLineOptions) {
+				o.length = 0
+			}),
+			expectedErr: passgen.ErrLengthMustBeGreaterThanZero,
 		},
 		{
 			name: "invalid length - negative",
-			options: &CommandLineOptions{
-				length:       -5,
-				avoidRepeats: DefaultAvoidRepeats,
-			},
-			expectedErr: ErrLengthMustBeGreaterThanZero,
+			options: newTestCommandLineOptions(func(o *CommandLineOptions) {
+				o.length = -5
+			}),
+			expectedErr: passgen.ErrLengthMustBeGreaterThanZero,
 		},
 		{
 			name: "invalid avoid repeats - negative",
-			options: &CommandLineOptions{
-				length:       DefaultPasswordLength,
-				avoidRepeats: -1,
-			},
-			expectedErr: ErrAvoidRepeatsMustBeEqualOrGreaterThanZero,
+			options: newTestCommandLineOptions(func(o *CommandLineOptions) {
+				o.avoidRepeats = -1
+			}),
+			expectedErr: passgen.ErrAvoidRepeatsMustBeEqualOrGreaterThanZero,
+		},
+		{
+			name: "invalid minimums - exceed length",
+			options: newTestCommandLineOptions(func(o *CommandLineOptions) {
+				o.length = 4
+				o.minLowercase = 3
+				o.minNumbers = 3
+			}),
+			expectedErr: passgen.ErrMinimumsExceedLength,
+		},
+		{
+			name: "invalid minimums - disabled charset",
+			options: newTestCommandLineOptions(func(o *CommandLineOptions) {
+				o.minSymbols = 1
+			}),
+			expectedErr: passgen.ErrMinimumForDisabledCharset,
+		},
+		{
+			name: "invalid count - zero",
+			options: newTestCommandLineOptions(func(o *CommandLineOptions) {
+				o.count = 0
+			}),
+			expectedErr: passgen.ErrCountMustBeGreaterThanZero,
+		},
+		{
+			name: "invalid format",
+			options: newTestCommandLineOptions(func(o *CommandLineOptions) {
+				o.format = "xml"
+			}),
+			expectedErr: ErrUnknownOutputFormat,
+		},
+		{
+			name: "invalid mode",
+			options: newTestCommandLineOptions(func(o *CommandLineOptions) {
+				o.mode = "random-words"
+			}),
+			expectedErr: ErrUnknownMode,
+		},
+		{
+			name: "invalid mode - words with pronounceable",
+			options: newTestCommandLineOptions(func(o *CommandLineOptions) {
+				o.mode = ModePronounceable
+				o.words = 4
+			}),
+			expectedErr: ErrWordsWithMode,
+		},
+		{
+			name: "invalid pronounceable digits",
+			options: newTestCommandLineOptions(func(o *CommandLineOptions) {
+				o.length = 4
+				o.mode = ModePronounceable
+				o.digits = 5
+			}),
+			expectedErr: passgen.ErrDigitsCannotExceedLength,
+		},
+		{
+			name: "invalid QR size",
+			options: newTestCommandLineOptions(func(o *CommandLineOptions) {
+				o.qrSize = 0
+			}),
+			expectedErr: passgen.ErrQrSizeMustBeGreaterThanZero,
+		},
+		{
+			name: "invalid QR file with count",
+			options: newTestCommandLineOptions(func(o *CommandLineOptions) {
+				o.count = 2
+				o.qrPng = "qr.png"
+			}),
+			expectedErr: ErrQrFileWithCount,
+		},
+		{
+			name: "invalid Wi-Fi without QR output",
+			options: newTestCommandLineOptions(func(o *CommandLineOptions) {
+				o.wifiSsid = "guest"
+			}),
+			expectedErr: ErrWifiWithoutQr,
+		},
+		{
+			name: "invalid Wi-Fi password length",
+			options: newTestCommandLineOptions(func(o *CommandLineOptions) {
+				o.length = 6
+				o.qrOutput = true
+				o.wifiSsid = "guest"
+			}),
+			expectedErr: passgen.ErrWifiPasswordLength,
+		},
+		{
+			name: "invalid Wi-Fi password length in bytes",
+			options: newTestCommandLineOptions(func(o *CommandLineOptions) {
+				o.length = 40
+				o.lowercase, o.uppercase, o.numbers, o.symbols = false, false, false, false
+				o.custom = "äöü"
+				o.qrOutput = true
+				o.wifiSsid = "guest"
+			}),
+			expectedErr: passgen.ErrWifiPasswordLength,
+		},
+		{
+			name: "invalid Wi-Fi passphrase length",
+			options: newTestCommandLineOptions(func(o *CommandLineOptions) {
+				o.words = 8
+				o.qrOutput = true
+				o.wifiSsid = "guest"
+			}),
+			expectedErr: passgen.ErrWifiPasswordLength,
+		},
+		{
+			name: "invalid Wi-Fi security",
+			options: newTestCommandLineOptions(func(o *CommandLineOptions) {
+				o.qrOutput = true
+				o.wifiSsid = "guest"
+				o.wifiSecurity = "WPA3"
+			}),
+			expectedErr: passgen.ErrUnknownWifiSecurity,
+		},
+		{
+			name: "invalid TOTP digits",
+			options: newTestCommandLineOptions(func(o *CommandLineOptions) {
+				o.mode = ModeTotp
+				o.totpDigits = 4
+			}),
+			expectedErr: passgen.ErrTotpDigitsOutOfRange,
+		},
+		{
+			name: "TOTP without account",
+			options: newTestCommandLineOptions(func(o *CommandLineOptions) {
+				o.mode = ModeTotp
+			}),
+			expectedErr: ErrTotpWithoutAccount,
+		},
+		{
+			name: "invalid Wi-Fi with TOTP",
+			options: newTestCommandLineOptions(func(o *CommandLineOptions) {
+				o.mode = ModeTotp
+				o.qrOutput = true
+				o.wifiSsid = "guest"
+				o.totpAccount = "ci"
+			}),
+			expectedErr: ErrWifiWithTotp,
+		},
+		{
+			name: "invalid clip with count",
+			options: newTestCommandLineOptions(func(o *CommandLineOptions) {
+				o.count = 2
+				o.clip = true
+			}),
+			expectedErr: ErrClipWithCount,
+		},
+		{
+			name: "invalid clip with format",
+			options: newTestCommandLineOptions(func(o *CommandLineOptions) {
+				o.format = FormatJSON
+				o.clip = true
+			}),
+			expectedErr: ErrClipWithFormat,
+		},
+		{
+			name: "invalid clip with QR code",
+			options: newTestCommandLineOptions(func(o *CommandLineOptions) {
+				o.qrOutput = true
+				o.clip = true
+			}),
+			expectedErr: ErrClipWithQr,
+		},
+		{
+			name: "invalid clip backend",
+			options: newTestCommandLineOptions(func(o *CommandLineOptions) {
+				o.clipBackend = "xerox"
+			}),
+			expectedErr: ErrUnknownClipboard,
+		},
+		{
+			name: "invalid QR level",
+			options: newTestCommandLineOptions(func(o *CommandLineOptions) {
+				o.qrLevel = "maximum"
+			}),
+			expectedErr: passgen.ErrUnknownQrLevel,
+		},
+		{
+			name: "invalid QR style",
+			options: newTestCommandLineOptions(func(o *CommandLineOptions) {
+				o.qrStyle = "color"
+			}),
+			expectedErr: passgen.ErrUnknownQrStyle,
+		},
+		{
+			name: "invalid QR margin",
+			options: newTestCommandLineOptions(func(o *CommandLineOptions) {
+				o.qrMargin = -1
+			}),
+			expectedErr: passgen.ErrQrMarginMustBeEqualOrGreaterThanZero,
+		},
+		{
+			name: "invalid words - negative",
+			options: newTestCommandLineOptions(func(o *CommandLineOptions) {
+				o.words = -1
+			}),
+			expectedErr: passgen.ErrWordsMustBeGreaterThanZero,
 		},
 		{
 			name: "multiple invalid arguments - length checked first",
-			options: &CommandLineOptions{
-				length:       -1,
-				avoidRepeats: -1,
-			},
-			expectedErr: ErrLengthMustBeGreaterThanZero,
+			options: newTestCommandLineOptions(func(o *CommandLineOptions) {
+				o.length = -1
+				o.avoidRepeats = -1
+			}),
+			expectedErr: passgen.ErrLengthMustBeGreaterThanZero,
 		},
 	}
 
@@ -394,7 +774,7 @@ func TestCommandLineOptionsToPasswordGeneratorOptions(t *testing.T) {
 	tests := []struct {
 		name     string
 		options  *CommandLineOptions
-		expected *PasswordGeneratorOptions
+		expected *passgen.PasswordGeneratorOptions
 	}{
 		{
 			name: "default values conversion",
@@ -408,7 +788,7 @@ func TestCommandLineOptionsToPasswordGeneratorOptions(t *testing.T) {
 				avoidRepeats: DefaultAvoidRepeats,
 				qrOutput:     false,
 			},
-			expected: &PasswordGeneratorOptions{
+			expected: &passgen.PasswordGeneratorOptions{
 				Length:       DefaultPasswordLength,
 				Lowercase:    true,
 				Uppercase:    true,
@@ -416,7 +796,6 @@ func TestCommandLineOptionsToPasswordGeneratorOptions(t *testing.T) {
 				Symbols:      false,
 				Custom:       "",
 				AvoidRepeats: DefaultAvoidRepeats,
-				QrCode:       false,
 			},
 		},
 		{
@@ -431,7 +810,7 @@ func TestCommandLineOptionsToPasswordGeneratorOptions(t *testing.T) {
 				avoidRepeats: 5,
 				qrOutput:     true,
 			},
-			expected: &PasswordGeneratorOptions{
+			expected: &passgen.PasswordGeneratorOptions{
 				Length:       20,
 				Lowercase:    false,
 				Uppercase:    false,
@@ -439,7 +818,6 @@ func TestCommandLineOptionsToPasswordGeneratorOptions(t *testing.T) {
 				Symbols:      true,
 				Custom:       "abc123!@#",
 				AvoidRepeats: 5,
-				QrCode:       true,
 			},
 		},
 		{
@@ -454,7 +832,7 @@ func TestCommandLineOptionsToPasswordGeneratorOptions(t *testing.T) {
 				avoidRepeats: 0,
 				qrOutput:     false,
 			},
-			expected: &PasswordGeneratorOptions{
+			expected: &passgen.PasswordGeneratorOptions{
 				Length:       1,
 				Lowercase:    true,
 				Uppercase:    false,
@@ -462,7 +840,6 @@ func TestCommandLineOptionsToPasswordGeneratorOptions(t *testing.T) {
 				Symbols:      false,
 				Custom:       "",
 				AvoidRepeats: 0,
-				QrCode:       false,
 			},
 		},
 	}
@@ -475,20 +852,7 @@ func TestCommandLineOptionsToPasswordGeneratorOptions(t *testing.T) {
 	}
 }
 
-func TestCommandLineOptionsToPasswordGeneratorOptionsFieldMapping(t *testing.T) {
-	options := &CommandLineOptions{qrOutput: true}
-	result := options.ToPasswordGeneratorOptions()
-	assert.True(t, result.QrCode)
-
-	options = &CommandLineOptions{qrOutput: false}
-	result = options.ToPasswordGeneratorOptions()
-	assert.False(t, result.QrCode)
-}
-
-func TestInitializeCommandLineSuccess(t *testing.T) {
-	originalArgs := os.Args
-	defer func() { os.Args = originalArgs }()
-
+func TestLoadCommandLineSuccess(t *testing.T) {
 	tests := []struct {
 		name     string
 		args     []string
@@ -496,7 +860,7 @@ func TestInitializeCommandLineSuccess(t *testing.T) {
 	}{
 		{
 			name: "default arguments",
-			args: []string{"testprogram"},
+			args: []string{},
 			validate: func(t *testing.T, options *CommandLineOptions) {
 				assert.Equal(t, DefaultPasswordLength, options.length)
 				assert.True(t, options.lowercase)
@@ -507,7 +871,7 @@ func TestInitializeCommandLineSuccess(t *testing.T) {
 		},
 		{
 			name: "valid custom arguments",
-			args: []string{"testprogram", "-l", "16", "--symbols=true", "-a", "2"},
+			args: []string{"-l", "16", "--symbols=true", "-a", "2"},
 			validate: func(t *testing.T, options *CommandLineOptions) {
 				assert.Equal(t, 16, options.length)
 				assert.True(t, options.symbols)
@@ -517,7 +881,6 @@ func TestInitializeCommandLineSuccess(t *testing.T) {
 		{
 			name: "complex valid arguments",
 			args: []string{
-				"testprogram",
 				"--length", "30",
 				"--lowercase=false",
 				"--uppercase=true",
@@ -542,9 +905,7 @@ func TestInitializeCommandLineSuccess(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			os.Args = tt.args
-
-			options, err := InitializeCommandLine()
+			options, err := loadCommandLine(GenerateCommand, tt.args, nil, io.Discard)
 
 			require.NoError(t, err)
 			require.NotNil(t, options)
@@ -553,10 +914,7 @@ func TestInitializeCommandLineSuccess(t *testing.T) {
 	}
 }
 
-func TestInitializeCommandLineValidationErrors(t *testing.T) {
-	originalArgs := os.Args
-	defer func() { os.Args = originalArgs }()
-
+func TestLoadCommandLineValidationErrors(t *testing.T) {
 	tests := []struct {
 		name        string
 		args        []string
@@ -564,31 +922,29 @@ func TestInitializeCommandLineValidationErrors(t *testing.T) {
 	}{
 		{
 			name:        "invalid length - zero",
-			args:        []string{"testprogram", "-l", "0"},
-			expectedErr: ErrLengthMustBeGreaterThanZero,
+			args:        []string{"-l", "0"},
+			expectedErr: passgen.ErrLengthMustBeGreaterThanZero,
 		},
 		{
 			name:        "invalid length - negative",
-			args:        []string{"testprogram", "--length", "-5"},
-			expectedErr: ErrLengthMustBeGreaterThanZero,
+			args:        []string{"--length", "-5"},
+			expectedErr: passgen.ErrLengthMustBeGreaterThanZero,
 		},
 		{
 			name:        "invalid avoid repeats - negative",
-			args:        []string{"testprogram", "-a", "-1"},
-			expectedErr: ErrAvoidRepeatsMustBeEqualOrGreaterThanZero,
+			args:        []string{"-a", "-1"},
+			expectedErr: passgen.ErrAvoidRepeatsMustBeEqualOrGreaterThanZero,
 		},
 		{
 			name:        "invalid avoid repeats - negative long flag",
-			args:        []string{"testprogram", "--avoid-repeats", "-3"},
-			expectedErr: ErrAvoidRepeatsMustBeEqualOrGreaterThanZero,
+			args:        []string{"--avoid-repeats", "-3"},
+			expectedErr: passgen.ErrAvoidRepeatsMustBeEqualOrGreaterThanZero,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			os.Args = tt.args
-
-			options, err := InitializeCommandLine()
+			options, err := loadCommandLine(GenerateCommand, tt.args, nil, io.Discard)
 
 			assert.Error(t, err)
 			assert.Nil(t, options)
@@ -597,11 +953,9 @@ func TestInitializeCommandLineValidationErrors(t *testing.T) {
 	}
 }
 
-func TestCommandLineInitializeCommandLine(t *testing.T) {
+func TestLoadCommandLine(t *testing.T) {
 	t.Run("invalid flags", func(t *testing.T) {
-		os.Args = []string{"passgen", "--invalid-arg"}
-
-		cmd, err := InitializeCommandLine()
+		cmd, err := loadCommandLine(GenerateCommand, []string{"--invalid-arg"}, nil, io.Discard)
 
 		assert.Error(t, err)
 		assert.Nil(t, cmd)
@@ -609,14 +963,12 @@ func TestCommandLineInitializeCommandLine(t *testing.T) {
 }
 
 func TestCommandLineParserParseInvalidFlags(t *testing.T) {
-	parser := NewCommandLineParser()
+	var output bytes.Buffer
+	parser := NewCommandLineParser().WithOutput(&output)
 
-	output := captureStderr(func() {
-		parser.Parse([]string{"--invalid-flag"})
-	})
+	parser.Parse([]string{"--invalid-flag"})
 
-	assert.NotEmpty(t, output)
-	assert.Contains(t, output, "flag provided but not defined")
+	assert.Contains(t, output.String(), "flag provided but not defined")
 }
 
 func TestIntegrationParseValidateConvert(t *testing.T) {
@@ -647,7 +999,51 @@ func TestIntegrationParseValidateConvert(t *testing.T) {
 	assert.True(t, genOptions.Symbols)
 	assert.Equal(t, "mycharset", genOptions.Custom)
 	assert.Equal(t, 2, genOptions.AvoidRepeats)
-	assert.True(t, genOptions.QrCode)
+	assert.True(t, options.qrOutput)
+}
+
+func TestCommandLineOptionsWarnings(t *testing.T) {
+	t.Run("no warnings for disjoint sets", func(t *testing.T) {
+		options := &CommandLineOptions{length: 12, lowercase: true, custom: "123"}
+
+		assert.Empty(t, options.Warnings())
+	})
+
+	t.Run("warns about overlapping sets", func(t *testing.T) {
+		options := &CommandLineOptions{length: 12, lowercase: true, custom: "abc"}
+
+		warnings := options.Warnings()
+
+		require.Len(t, warnings, 1)
+		assert.Contains(t, warnings[0], `"abc"`)
+		assert.Contains(t, warnings[0], "effective pool size: 26")
+	})
+
+	t.Run("warns about repeats within a set", func(t *testing.T) {
+		options := &CommandLineOptions{length: 12, custom: "aab"}
+
+		warnings := options.Warnings()
+
+		require.Len(t, warnings, 1)
+		assert.Contains(t, warnings[0], `"a"`)
+		assert.Contains(t, warnings[0], "repeated within a character set")
+		assert.Contains(t, warnings[0], "effective pool size: 2")
+	})
+
+	t.Run("warns about an insecure random source", func(t *testing.T) {
+		options := &CommandLineOptions{length: 12, lowercase: true, random: passgen.NewSeededRandomSource("debug")}
+
+		warnings := options.Warnings()
+
+		require.Len(t, warnings, 1)
+		assert.Contains(t, warnings[0], "predictable")
+	})
+
+	t.Run("no warning for the crypto random source", func(t *testing.T) {
+		options := &CommandLineOptions{length: 12, lowercase: true, random: passgen.CryptoRandom}
+
+		assert.Empty(t, options.Warnings())
+	})
 }
 
 func TestCommandLineConstants(t *testing.T) {
@@ -665,12 +1061,11 @@ func BenchmarkCommandLineParserParse(b *testing.B) {
 }
 
 func BenchmarkGeneratePasswordWitAllCharset(b *testing.B) {
-	os.Args = []string{"passgen", "-l", "50", "-a", "3", "-L", "-U", "-N", "-S"}
-	cmd, _ := InitializeCommandLine()
+	cmd, _ := loadCommandLine(GenerateCommand, []string{"-l", "50", "-a", "3", "-L", "-U", "-N", "-S"}, nil, io.Discard)
 
 	b.ResetTimer()
 	for b.Loop() {
-		GeneratePassword(*cmd.ToPasswordGeneratorOptions())
+		passgen.GeneratePassword(*cmd.ToPasswordGeneratorOptions())
 	}
 }
 
@@ -681,12 +1076,8 @@ func TestPrintVersion(t *testing.T) {
 		date    = "2025-09-05T00:00:00Z"
 	)
 
-	output := captureStdout(func() {
-		PrintVersion(version, commit, date)
-	})
+	var output bytes.Buffer
+	PrintVersion(&output, version, commit, date)
 
-	assert.NotEmpty(t, output)
-	assert.Contains(t, output, version)
-	assert.Contains(t, output, commit[:9])
-	assert.Contains(t, output, "2025-09-05")
+	assert.Equal(t, "passgen version 0.0.1 (d02718917) released at 2025-09-05\n", output.String())
 }
@@ -0,0 +1,277 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"time"
+)
+
+const (
+	GenerateCommand = "generate"
+	PhraseCommand   = "phrase"
+	VersionCommand  = "version"
+	HelpCommand     = "help"
+)
+
+// Exit codes follow sysexits.h.
+const (
+	ExitOK      = 0
+	ExitUsage   = 64
+	ExitIOError = 74
+)
+
+var ErrUnknownCommand = errors.New("Unknown command.")
+
+// Build information, set by main from the values injected at link time.
+var (
+	Version = "dev"
+	Commit  = "none"
+	Date    = "unknown"
+)
+
+type Command struct {
+	Name    string
+	Summary string
+	Run     func(ctx context.Context, args, environ []string, stdin io.Reader, stdout, stderr io.Writer) error
+
+	// Flags returns the options of the command bound to options, in the order
+	// they are shown in help.
+	Flags func(options *CommandLineOptions) []flagDefinition
+}
+
+func Commands() []Command {
+	return []Command{
+		{
+			Name:    GenerateCommand,
+			Summary: "Generate passwords, passphrases or TOTP secrets (default)",
+			Run: func(ctx context.Context, args, environ []string, stdin io.Reader, stdout, stderr io.Writer) error {
+				return runGenerate(ctx, GenerateCommand, args, environ, stdout, stderr)
+			},
+			Flags: generateFlags,
+		},
+		{
+			Name:    PhraseCommand,
+			Summary: "Generate passphrases from the EFF large wordlist",
+			Run: func(ctx context.Context, args, environ []string, stdin io.Reader, stdout, stderr io.Writer) error {
+				return runGenerate(ctx, PhraseCommand, args, environ, stdout, stderr)
+			},
+			Flags: phraseFlags,
+		},
+		{
+			Name:    CheckCommand,
+			Summary: "Rate the strength of a password read from stdin",
+			Run: func(ctx context.Context, args, environ []string, stdin io.Reader, stdout, stderr io.Writer) error {
+				return usage(RunCheck(args, stdin, stdout, stderr))
+			},
+			Flags: checkFlags,
+		},
+		{
+			Name:    TotpCodeCommand,
+			Summary: "Print the current code for a TOTP secret read from stdin",
+			Run: func(ctx context.Context, args, environ []string, stdin io.Reader, stdout, stderr io.Writer) error {
+				return usage(RunTotpCode(args, stdin, stdout, stderr, time.Now()))
+			},
+			Flags: totpCodeFlags,
+		},
+		{
+			Name:    VersionCommand,
+			Summary: "Print the version",
+			Run:     runVersion,
+			Flags:   helpOnlyFlags,
+		},
+		{
+			Name:    CompletionCommand,
+			Summary: "Print a shell completion script for bash, zsh or fish",
+			Run:     runCompletion,
+			Flags:   helpOnlyFlags,
+		},
+		{
+			Name:    ManCommand,
+			Summary: "Print the manual page or a markdown reference of the options",
+			Run:     runMan,
+			Flags:   manFlags,
+		},
+		{
+			Name:    HelpCommand,
+			Summary: "Show the help of a command",
+			Run:     runHelp,
+			Flags:   helpOnlyFlags,
+		},
+	}
+}
+
+// flagDefinitions returns the options of the command with their defaults.
+func (c Command) flagDefinitions() []flagDefinition {
+	return c.Flags(newCommandLineOptions(c.Name))
+}
+
+func lookupCommand(name string) (Command, bool) {
+	for _, command := range Commands() {
+		if command.Name == name {
+			return command, true
+		}
+	}
+
+	return Command{}, false
+}
+
+// Run runs the command line and returns the exit code. It neither reads
+// os.Args or the environment nor writes to the standard streams itself, so the
+// whole CLI can be driven from tests or embedded into other tools. Besides the
+// arguments and streams, Run takes the environment as environ, formatted like
+// os.Environ: the PASSGEN_* options, the configuration directory, the terminal
+// width and the clipboard detection are all read from it.
+func Run(ctx context.Context, args, environ []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	err := runCommand(ctx, args, environ, stdin, stdout, stderr)
+
+	switch {
+	case err == nil || err == flag.ErrHelp:
+		return ExitOK
+	case isUsageError(err):
+		fmt.Fprintln(stderr, err)
+		return ExitUsage
+	default:
+		fmt.Fprintln(stderr, err)
+		return ExitIOError
+	}
+}
+
+// runCommand runs the command named by the first argument. Without a command
+// name, all arguments are passed to generate, so "passgen -l 16" keeps working.
+func runCommand(ctx context.Context, args, environ []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	if len(args) > 0 {
+		switch args[0] {
+		case "-h", "-help", "--help":
+			printCommandsUsage(stderr)
+			return flag.ErrHelp
+		case "-v", "--version":
+			return runVersion(ctx, args[1:], environ, stdin, stdout, stderr)
+		}
+
+		if command, ok := lookupCommand(args[0]); ok {
+			return command.Run(ctx, args[1:], environ, stdin, stdout, stderr)
+		}
+	}
+
+	return runGenerate(ctx, GenerateCommand, args, environ, stdout, stderr)
+}
+
+// usageError marks errors caused by the command line rather than by
+// generating or writing the output, main exits with a different code.
+type usageError struct {
+	err error
+}
+
+func (e *usageError) Error() string {
+	return e.err.Error()
+}
+
+func (e *usageError) Unwrap() error {
+	return e.err
+}
+
+func usage(err error) error {
+	if err == nil || err == flag.ErrHelp {
+		return err
+	}
+
+	return &usageError{err: err}
+}
+
+func isUsageError(err error) bool {
+	var usageErr *usageError
+	return errors.As(err, &usageErr)
+}
+
+func runGenerate(ctx context.Context, command string, args, environ []string, stdout, stderr io.Writer) error {
+	options, err := loadCommandLine(command, args, environ, stderr)
+	if err != nil {
+		return usage(err)
+	}
+
+	for _, warning := range options.Warnings() {
+		fmt.Fprintln(stderr, warning)
+	}
+
+	passwords, err := options.Generate()
+	if err != nil {
+		return err
+	}
+
+	if err := options.WriteOutput(stdout, stderr, passwords); err != nil {
+		return err
+	}
+
+	if !options.Clip() {
+		return nil
+	}
+
+	clipboard, err := NewClipboard(options.ClipBackend(), stderr, options.environ)
+	if err != nil {
+		return usage(err)
+	}
+
+	return options.WriteClipboard(ctx, clipboard, stderr, passwords)
+}
+
+func runVersion(ctx context.Context, args, environ []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	flagSet := newVersionFlagSet(stderr)
+
+	if err := flagSet.Parse(args); err != nil {
+		return usage(err)
+	}
+
+	if flagSet.NArg() > 0 {
+		return usage(fmt.Errorf("%s: %w", flagSet.Arg(0), ErrUnknownCommand))
+	}
+
+	PrintVersion(stdout, Version, Commit, Date)
+	return nil
+}
+
+func newVersionFlagSet(stderr io.Writer) *flag.FlagSet {
+	flagSet := newFlagSet(VersionCommand, nil, stderr)
+	flagSet.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: %s %s\n\n", ProgramName, VersionCommand)
+		fmt.Fprintf(stderr, "Print the version, commit and release date.\n")
+	}
+
+	return flagSet
+}
+
+func runHelp(ctx context.Context, args, environ []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	if len(args) == 0 {
+		printCommandsUsage(stderr)
+		return nil
+	}
+
+	command, ok := lookupCommand(args[0])
+	if !ok || command.Name == HelpCommand {
+		return usage(fmt.Errorf("%s: %w", args[0], ErrUnknownCommand))
+	}
+
+	if err := command.Run(ctx, []string{"--help"}, environ, stdin, stdout, stderr); err != flag.ErrHelp {
+		return err
+	}
+
+	return nil
+}
+
+func printCommandsUsage(w io.Writer) {
+	fmt.Fprintf(w, "Usage: %s [command] [options]\n\n", ProgramName)
+	fmt.Fprintf(w, "A secure password generator with customizable options.\n\n")
+
+	fmt.Fprintf(w, "Commands:\n")
+	for _, command := range Commands() {
+		fmt.Fprintf(w, "  %-12s%s\n", command.Name, command.Summary)
+	}
+
+	fmt.Fprintf(w, "\nGlobal options:\n")
+	printFlags(w, globalFlags)
+
+	fmt.Fprintf(w, "\nWithout a command the options are passed to %s.\n", GenerateCommand)
+	fmt.Fprintf(w, "Run '%s %s <command>' for the options of a command.\n", ProgramName, HelpCommand)
+}
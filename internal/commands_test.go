@@ -0,0 +1,228 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"strings"
+	"testing"
+
+	"github.com/amirhossein-fzl/passgen/pkg/passgen"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func runTestCommand(t *testing.T, stdin string, args ...string) (string, string, error) {
+	t.Helper()
+
+	return runTestCommandWithEnvironment(t, nil, stdin, args...)
+}
+
+func runTestCommandWithEnvironment(t *testing.T, environ []string, stdin string, args ...string) (string, string, error) {
+	t.Helper()
+
+	var stdout, stderr bytes.Buffer
+	err := runCommand(context.Background(), args, environ, strings.NewReader(stdin), &stdout, &stderr)
+
+	return stdout.String(), stderr.String(), err
+}
+
+func TestRunCommand(t *testing.T) {
+	t.Run("generate is the default command", func(t *testing.T) {
+		stdout, _, err := runTestCommand(t, "", "-l", "20")
+
+		require.NoError(t, err)
+		assert.Len(t, strings.TrimSpace(stdout), 20)
+	})
+
+	t.Run("generate by name", func(t *testing.T) {
+		stdout, _, err := runTestCommand(t, "", GenerateCommand, "-l", "20", "-n", "2")
+
+		require.NoError(t, err)
+		assert.Len(t, strings.Fields(stdout), 2)
+	})
+
+	t.Run("phrase", func(t *testing.T) {
+		stdout, _, err := runTestCommand(t, "", PhraseCommand, "--separator", "_")
+
+		require.NoError(t, err)
+		assert.Len(t, strings.Split(strings.TrimSpace(stdout), "_"), DefaultPhraseWords)
+	})
+
+	t.Run("phrase does not know password options", func(t *testing.T) {
+		_, stderr, err := runTestCommand(t, "", PhraseCommand, "-l", "5")
+
+		assert.True(t, isUsageError(err))
+		assert.Contains(t, stderr, "Usage: passgen phrase")
+	})
+
+	t.Run("phrase needs words", func(t *testing.T) {
+		_, _, err := runTestCommand(t, "", PhraseCommand, "-w", "0")
+
+		assert.True(t, isUsageError(err))
+		assert.ErrorIs(t, err, passgen.ErrWordsMustBeGreaterThanZero)
+	})
+
+	t.Run("check", func(t *testing.T) {
+		stdout, _, err := runTestCommand(t, "secret\n", CheckCommand)
+
+		require.NoError(t, err)
+		assert.Contains(t, stdout, "Length: 6\n")
+	})
+
+	t.Run("unknown command", func(t *testing.T) {
+		_, _, err := runTestCommand(t, "", "genrate")
+
+		assert.True(t, isUsageError(err))
+		assert.ErrorIs(t, err, ErrUnknownCommand)
+		assert.EqualError(t, err, "genrate: Unknown command.")
+	})
+
+	t.Run("generation errors are not usage errors", func(t *testing.T) {
+		_, _, err := runTestCommand(t, "", PhraseCommand, "-w", "1", "-n", "7777", "--unique")
+
+		assert.Equal(t, passgen.ErrNotEnoughUniquePasswords, err)
+		assert.False(t, isUsageError(err))
+	})
+}
+
+func TestRunCommandHelp(t *testing.T) {
+	t.Run("lists commands", func(t *testing.T) {
+		for _, args := range [][]string{{"-h"}, {"--help"}, {HelpCommand}} {
+			_, stderr, err := runTestCommand(t, "", args...)
+
+			if args[0] == HelpCommand {
+				assert.NoError(t, err)
+			} else {
+				assert.Equal(t, flag.ErrHelp, err)
+			}
+			for _, command := range Commands() {
+				assert.Contains(t, stderr, "  "+command.Name+" ")
+			}
+		}
+	})
+
+	t.Run("help of a command", func(t *testing.T) {
+		for _, command := range []string{GenerateCommand, PhraseCommand, CheckCommand, TotpCodeCommand, VersionCommand} {
+			_, stderr, err := runTestCommand(t, "", HelpCommand, command)
+
+			assert.NoError(t, err, command)
+			assert.Contains(t, stderr, "Usage: passgen", command)
+		}
+	})
+
+	t.Run("generate help", func(t *testing.T) {
+		_, stderr, err := runTestCommand(t, "", "-l", "16", "--help")
+
+		assert.Equal(t, flag.ErrHelp, err)
+		assert.Contains(t, stderr, "--avoid-repeats")
+		assert.Contains(t, stderr, "--with-number")
+	})
+
+	t.Run("help of an unknown command", func(t *testing.T) {
+		_, _, err := runTestCommand(t, "", HelpCommand, "nope")
+
+		assert.ErrorIs(t, err, ErrUnknownCommand)
+	})
+}
+
+func TestRunCommandHelpWithBrokenConfig(t *testing.T) {
+	environ := writeTestConfigHome(t, "length = ")
+
+	for _, args := range [][]string{{"--help"}, {"-l", "16", "-h"}, {PhraseCommand, "-h"}, {HelpCommand, PhraseCommand}} {
+		_, stderr, err := runTestCommandWithEnvironment(t, environ, "", args...)
+
+		assert.False(t, isUsageError(err), args)
+		assert.Contains(t, stderr, "Usage: passgen", args)
+	}
+
+	_, _, err := runTestCommandWithEnvironment(t, environ, "", "-l", "16")
+
+	assert.True(t, isUsageError(err))
+	assert.ErrorContains(t, err, ConfigFileName)
+}
+
+func TestRunCommandSharesConfig(t *testing.T) {
+	environ := writeTestConfigHome(t, "length = 20\nseparator = \".\"\n")
+
+	stdout, _, err := runTestCommandWithEnvironment(t, environ, "")
+	require.NoError(t, err)
+	assert.Len(t, strings.TrimSpace(stdout), 20)
+
+	stdout, _, err = runTestCommandWithEnvironment(t, environ, "", PhraseCommand, "-w", "3")
+	require.NoError(t, err)
+	assert.Len(t, strings.Split(strings.TrimSpace(stdout), "."), 3)
+}
+
+func TestRun(t *testing.T) {
+	run := func(stdin string, args ...string) (int, string, string) {
+		var stdout, stderr bytes.Buffer
+		code := Run(context.Background(), args, nil, strings.NewReader(stdin), &stdout, &stderr)
+
+		return code, stdout.String(), stderr.String()
+	}
+
+	t.Run("generates a password", func(t *testing.T) {
+		code, stdout, stderr := run("", "-l", "16")
+
+		assert.Equal(t, ExitOK, code)
+		assert.Len(t, strings.TrimSpace(stdout), 16)
+		assert.Empty(t, stderr)
+	})
+
+	t.Run("reads options from the given environment", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+		code := Run(context.Background(), []string{PhraseCommand}, []string{"PASSGEN_WORDS=3", "PASSGEN_SEPARATOR=."}, strings.NewReader(""), &stdout, &stderr)
+
+		assert.Equal(t, ExitOK, code, stderr.String())
+		assert.Len(t, strings.Split(strings.TrimSpace(stdout.String()), "."), 3)
+	})
+
+	t.Run("prints QR codes", func(t *testing.T) {
+		code, stdout, _ := run("", "--insecure-seed", "qr", "-l", "16", "-q", "--qr-style", "ascii")
+
+		assert.Equal(t, ExitOK, code)
+		assert.Contains(t, stdout, "##")
+		assert.Contains(t, stdout, "Password: ")
+	})
+
+	t.Run("prints the version", func(t *testing.T) {
+		for _, args := range [][]string{{"-v"}, {"--version"}, {VersionCommand}} {
+			code, stdout, _ := run("", args...)
+
+			assert.Equal(t, ExitOK, code)
+			assert.Equal(t, "passgen version dev (none) released at unknown\n", stdout)
+		}
+	})
+
+	t.Run("help exits successfully", func(t *testing.T) {
+		code, stdout, stderr := run("", "--help")
+
+		assert.Equal(t, ExitOK, code)
+		assert.Empty(t, stdout)
+		assert.Contains(t, stderr, "Commands:")
+	})
+
+	t.Run("invalid options", func(t *testing.T) {
+		code, stdout, stderr := run("", "-l", "0")
+
+		assert.Equal(t, ExitUsage, code)
+		assert.Empty(t, stdout)
+		assert.Equal(t, passgen.ErrLengthMustBeGreaterThanZero.Error()+"\n", stderr)
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		code, _, stderr := run("", CheckCommand)
+
+		assert.Equal(t, ExitUsage, code)
+		assert.Contains(t, stderr, ErrCheckPasswordMissing.Error())
+	})
+
+	t.Run("generation failure", func(t *testing.T) {
+		code, stdout, stderr := run("", PhraseCommand, "-w", "1", "-n", "7777", "--unique")
+
+		assert.Equal(t, ExitIOError, code)
+		assert.Empty(t, stdout)
+		assert.Contains(t, stderr, passgen.ErrNotEnoughUniquePasswords.Error())
+	})
+}
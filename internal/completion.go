@@ -0,0 +1,289 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+)
+
+const CompletionCommand = "completion"
+
+const (
+	ShellBash = "bash"
+	ShellZsh  = "zsh"
+	ShellFish = "fish"
+)
+
+// completionProfiles makes the completion command print the profile names of
+// the configuration file. The scripts call it while completing, so profiles
+// added later complete without generating the script again.
+const completionProfiles = "profiles"
+
+var ErrUnknownShell = errors.New("Shell must be one of bash, zsh or fish.")
+
+func dashed(name string) string {
+	if len(name) == 1 {
+		return "-" + name
+	}
+
+	return "--" + name
+}
+
+// flagWords returns all flag names with dashes and the names of the flags
+// that take a value.
+func flagWords(flags []flagDefinition) (all, values []string) {
+	for _, f := range flags {
+		for _, name := range f.names() {
+			all = append(all, dashed(name))
+			if f.arg != "" {
+				values = append(values, dashed(name))
+			}
+		}
+	}
+
+	return all, values
+}
+
+func commandNames(commands []Command) []string {
+	names := make([]string, 0, len(commands))
+	for _, command := range commands {
+		names = append(names, command.Name)
+	}
+
+	return names
+}
+
+// commandArguments returns the words completed after the flags of a command.
+func commandArguments(command string, commands []Command) []string {
+	switch command {
+	case HelpCommand:
+		return commandNames(commands)
+	case CompletionCommand:
+		return []string{ShellBash, ShellZsh, ShellFish}
+	}
+
+	return nil
+}
+
+func writeBashCompletion(w io.Writer, commands []Command) {
+	names := strings.Join(commandNames(commands), " ")
+
+	fmt.Fprintf(w, "# bash completion for %s, generated by \"%s %s %s\"\n\n", ProgramName, ProgramName, CompletionCommand, ShellBash)
+	fmt.Fprintf(w, "_%s() {\n", ProgramName)
+	fmt.Fprintf(w, "    local cur=\"${COMP_WORDS[COMP_CWORD]}\" prev=\"${COMP_WORDS[COMP_CWORD-1]}\"\n")
+	fmt.Fprintf(w, "    local command=%s flags values arguments\n\n", GenerateCommand)
+
+	fmt.Fprintf(w, "    if [[ $COMP_CWORD -gt 1 ]]; then\n")
+	fmt.Fprintf(w, "        case \"${COMP_WORDS[1]}\" in\n")
+	fmt.Fprintf(w, "            %s) command=\"${COMP_WORDS[1]}\" ;;\n", strings.ReplaceAll(names, " ", "|"))
+	fmt.Fprintf(w, "        esac\n")
+	fmt.Fprintf(w, "    fi\n\n")
+
+	fmt.Fprintf(w, "    if [[ $prev == --profile ]]; then\n")
+	fmt.Fprintf(w, "        COMPREPLY=($(compgen -W \"$(%s %s %s 2>/dev/null)\" -- \"$cur\"))\n", ProgramName, CompletionCommand, completionProfiles)
+	fmt.Fprintf(w, "        return\n")
+	fmt.Fprintf(w, "    fi\n\n")
+
+	fmt.Fprintf(w, "    case \"$command\" in\n")
+	for _, command := range commands {
+		all, values := flagWords(command.flagDefinitions())
+
+		fmt.Fprintf(w, "        %s)\n", command.Name)
+		fmt.Fprintf(w, "            flags=\"%s\"\n", strings.Join(all, " "))
+		fmt.Fprintf(w, "            values=\"%s\"\n", strings.Join(values, " "))
+		fmt.Fprintf(w, "            arguments=\"%s\"\n", strings.Join(commandArguments(command.Name, commands), " "))
+		fmt.Fprintf(w, "            ;;\n")
+	}
+	fmt.Fprintf(w, "    esac\n\n")
+
+	fmt.Fprintf(w, "    # Leave the values of flags to the default completion, usually file names.\n")
+	fmt.Fprintf(w, "    if [[ \" $values \" == *\" $prev \"* ]]; then\n")
+	fmt.Fprintf(w, "        return\n")
+	fmt.Fprintf(w, "    fi\n\n")
+
+	fmt.Fprintf(w, "    if [[ $COMP_CWORD -eq 1 ]]; then\n")
+	fmt.Fprintf(w, "        arguments=\"%s\"\n", names)
+	fmt.Fprintf(w, "    fi\n\n")
+
+	fmt.Fprintf(w, "    if [[ $cur == -* ]]; then\n")
+	fmt.Fprintf(w, "        COMPREPLY=($(compgen -W \"$flags\" -- \"$cur\"))\n")
+	fmt.Fprintf(w, "    else\n")
+	fmt.Fprintf(w, "        COMPREPLY=($(compgen -W \"$arguments\" -- \"$cur\"))\n")
+	fmt.Fprintf(w, "    fi\n")
+	fmt.Fprintf(w, "}\n\n")
+
+	fmt.Fprintf(w, "complete -o default -F _%s %s\n", ProgramName, ProgramName)
+}
+
+func writeZshCompletion(w io.Writer, commands []Command) {
+	names := strings.Join(commandNames(commands), " ")
+
+	fmt.Fprintf(w, "#compdef %s\n", ProgramName)
+	fmt.Fprintf(w, "# zsh completion for %s, generated by \"%s %s %s\"\n\n", ProgramName, ProgramName, CompletionCommand, ShellZsh)
+	fmt.Fprintf(w, "_%s() {\n", ProgramName)
+	fmt.Fprintf(w, "  local command=%s\n", GenerateCommand)
+	fmt.Fprintf(w, "  local -a flags values arguments\n\n")
+
+	fmt.Fprintf(w, "  if (( CURRENT > 2 )); then\n")
+	fmt.Fprintf(w, "    case ${words[2]} in\n")
+	fmt.Fprintf(w, "      %s) command=${words[2]} ;;\n", strings.ReplaceAll(names, " ", "|"))
+	fmt.Fprintf(w, "    esac\n")
+	fmt.Fprintf(w, "  fi\n\n")
+
+	fmt.Fprintf(w, "  if [[ ${words[CURRENT-1]} == --profile ]]; then\n")
+	fmt.Fprintf(w, "    compadd -- ${(f)\"$(%s %s %s 2>/dev/null)\"}\n", ProgramName, CompletionCommand, completionProfiles)
+	fmt.Fprintf(w, "    return\n")
+	fmt.Fprintf(w, "  fi\n\n")
+
+	fmt.Fprintf(w, "  case $command in\n")
+	for _, command := range commands {
+		all, values := flagWords(command.flagDefinitions())
+
+		fmt.Fprintf(w, "    %s)\n", command.Name)
+		fmt.Fprintf(w, "      flags=(%s)\n", strings.Join(all, " "))
+		fmt.Fprintf(w, "      values=(%s)\n", strings.Join(values, " "))
+		fmt.Fprintf(w, "      arguments=(%s)\n", strings.Join(commandArguments(command.Name, commands), " "))
+		fmt.Fprintf(w, "      ;;\n")
+	}
+	fmt.Fprintf(w, "  esac\n\n")
+
+	fmt.Fprintf(w, "  if (( ${values[(Ie)${words[CURRENT-1]}]} )); then\n")
+	fmt.Fprintf(w, "    _files\n")
+	fmt.Fprintf(w, "    return\n")
+	fmt.Fprintf(w, "  fi\n\n")
+
+	fmt.Fprintf(w, "  if (( CURRENT == 2 )); then\n")
+	fmt.Fprintf(w, "    arguments=(%s)\n", names)
+	fmt.Fprintf(w, "  fi\n\n")
+
+	fmt.Fprintf(w, "  if [[ ${words[CURRENT]} == -* ]]; then\n")
+	fmt.Fprintf(w, "    compadd -- $flags\n")
+	fmt.Fprintf(w, "  elif (( ${#arguments} )); then\n")
+	fmt.Fprintf(w, "    compadd -- $arguments\n")
+	fmt.Fprintf(w, "  else\n")
+	fmt.Fprintf(w, "    _files\n")
+	fmt.Fprintf(w, "  fi\n")
+	fmt.Fprintf(w, "}\n\n")
+
+	fmt.Fprintf(w, "if [[ $zsh_eval_context[-1] == loadautofunc ]]; then\n")
+	fmt.Fprintf(w, "  _%s \"$@\"\n", ProgramName)
+	fmt.Fprintf(w, "else\n")
+	fmt.Fprintf(w, "  compdef _%s %s\n", ProgramName, ProgramName)
+	fmt.Fprintf(w, "fi\n")
+}
+
+func writeFishCompletion(w io.Writer, commands []Command) {
+	names := strings.Join(commandNames(commands), " ")
+	quote := strings.NewReplacer(`\`, `\\`, `'`, `\'`)
+
+	fmt.Fprintf(w, "# fish completion for %s, generated by \"%s %s %s\"\n\n", ProgramName, ProgramName, CompletionCommand, ShellFish)
+	fmt.Fprintf(w, "function __%s_command\n", ProgramName)
+	fmt.Fprintf(w, "    set -l tokens (commandline -opc)\n")
+	fmt.Fprintf(w, "    if set -q tokens[2]; and contains -- $tokens[2] %s\n", names)
+	fmt.Fprintf(w, "        echo $tokens[2]\n")
+	fmt.Fprintf(w, "    else\n")
+	fmt.Fprintf(w, "        echo %s\n", GenerateCommand)
+	fmt.Fprintf(w, "    end\n")
+	fmt.Fprintf(w, "end\n\n")
+
+	fmt.Fprintf(w, "function __%s_using\n", ProgramName)
+	fmt.Fprintf(w, "    test (__%s_command) = $argv[1]\n", ProgramName)
+	fmt.Fprintf(w, "end\n\n")
+
+	fmt.Fprintf(w, "complete -c %s -f\n", ProgramName)
+	for _, command := range commands {
+		fmt.Fprintf(w, "complete -c %s -n 'test (count (commandline -opc)) -eq 1' -a %s -d '%s'\n",
+			ProgramName, command.Name, quote.Replace(command.Summary))
+	}
+
+	for _, command := range commands {
+		condition := fmt.Sprintf("__%s_using %s", ProgramName, command.Name)
+		fmt.Fprintln(w)
+
+		for _, f := range command.flagDefinitions() {
+			fmt.Fprintf(w, "complete -c %s -n '%s'", ProgramName, condition)
+			if f.short != "" {
+				fmt.Fprintf(w, " -s %s", f.short)
+			}
+			fmt.Fprintf(w, " -l %s", f.long)
+
+			switch {
+			case f.long == "profile":
+				fmt.Fprintf(w, " -x -a '(%s %s %s 2>/dev/null)'", ProgramName, CompletionCommand, completionProfiles)
+			case f.arg != "":
+				fmt.Fprintf(w, " -r -F")
+			}
+			fmt.Fprintf(w, " -d '%s'\n", quote.Replace(f.usage))
+		}
+
+		if arguments := commandArguments(command.Name, commands); len(arguments) > 0 {
+			fmt.Fprintf(w, "complete -c %s -n '%s; and test (count (commandline -opc)) -eq 2' -a '%s'\n",
+				ProgramName, condition, strings.Join(arguments, " "))
+		}
+	}
+}
+
+func writeProfileNames(w io.Writer, environ []string) error {
+	path, err := ConfigPath(environ)
+	if err != nil {
+		return nil
+	}
+
+	config, err := LoadConfig(path)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(config.Profiles)) {
+		fmt.Fprintln(w, name)
+	}
+
+	return nil
+}
+
+func runCompletion(ctx context.Context, args, environ []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	flagSet := newCompletionFlagSet(stderr)
+
+	if err := flagSet.Parse(args); err != nil {
+		return usage(err)
+	}
+
+	if flagSet.NArg() != 1 {
+		return usage(ErrUnknownShell)
+	}
+
+	switch flagSet.Arg(0) {
+	case ShellBash:
+		writeBashCompletion(stdout, Commands())
+	case ShellZsh:
+		writeZshCompletion(stdout, Commands())
+	case ShellFish:
+		writeFishCompletion(stdout, Commands())
+	case completionProfiles:
+		return writeProfileNames(stdout, environ)
+	default:
+		return usage(ErrUnknownShell)
+	}
+
+	return nil
+}
+
+func newCompletionFlagSet(stderr io.Writer) *flag.FlagSet {
+	flagSet := newFlagSet(CompletionCommand, nil, stderr)
+	flagSet.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: %s %s <shell>\n\n", ProgramName, CompletionCommand)
+		fmt.Fprintf(stderr, "Print a completion script for bash, zsh or fish.\n\n")
+		fmt.Fprintf(stderr, "Installation:\n")
+		fmt.Fprintf(stderr, "  bash\tsource <(%s %s bash) in ~/.bashrc\n", ProgramName, CompletionCommand)
+		fmt.Fprintf(stderr, "  zsh\t%s %s zsh > \"${fpath[1]}/_%s\"\n", ProgramName, CompletionCommand, ProgramName)
+		fmt.Fprintf(stderr, "  fish\t%s %s fish > ~/.config/fish/completions/%s.fish\n", ProgramName, CompletionCommand, ProgramName)
+		fmt.Fprintf(stderr, "\nProfile names are read from the configuration file while completing.\n")
+	}
+
+	return flagSet
+}
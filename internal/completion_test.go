@@ -0,0 +1,88 @@
+package internal
+
+import (
+	"os/exec"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunCompletion(t *testing.T) {
+	for _, shell := range []string{ShellBash, ShellZsh, ShellFish} {
+		t.Run(shell+" completes every flag", func(t *testing.T) {
+			stdout, _, err := runTestCommand(t, "", CompletionCommand, shell)
+			require.NoError(t, err)
+
+			for _, command := range Commands() {
+				assert.Contains(t, stdout, command.Name)
+
+				for _, f := range command.flagDefinitions() {
+					for _, name := range f.names() {
+						pattern := regexp.QuoteMeta(dashed(name))
+						if shell == ShellFish {
+							pattern = "-[sl] " + regexp.QuoteMeta(name)
+						}
+
+						assert.Regexp(t, "[ (\"]"+pattern+"[ )\"\n]", stdout)
+					}
+				}
+			}
+
+			assert.Contains(t, stdout, "passgen completion profiles")
+		})
+	}
+
+	t.Run("fish describes flags", func(t *testing.T) {
+		stdout, _, err := runTestCommand(t, "", CompletionCommand, ShellFish)
+		require.NoError(t, err)
+
+		assert.Contains(t, stdout, "-s l -l length -r -F -d 'Password length'")
+		assert.Contains(t, stdout, "-l wifi-hidden -d 'Mark the Wi-Fi network as hidden'")
+		assert.Contains(t, stdout, "-s L -l lowercase -d 'Include lowercase letters (a-z)'")
+	})
+
+	t.Run("bash script is valid", func(t *testing.T) {
+		bash, err := exec.LookPath("bash")
+		if err != nil {
+			t.Skip("bash is not installed")
+		}
+
+		stdout, _, err := runTestCommand(t, "", CompletionCommand, ShellBash)
+		require.NoError(t, err)
+
+		script := stdout + `
+COMP_WORDS=(passgen phrase --wo); COMP_CWORD=2; _passgen; echo "${COMPREPLY[*]}"
+COMP_WORDS=(passgen --avoid); COMP_CWORD=1; _passgen; echo "${COMPREPLY[*]}"
+COMP_WORDS=(passgen help ph); COMP_CWORD=2; _passgen; echo "${COMPREPLY[*]}"
+`
+		output, err := exec.Command(bash, "-c", script).CombinedOutput()
+
+		require.NoError(t, err, string(output))
+		assert.Equal(t, "--words\n--avoid-repeats\nphrase\n", string(output))
+	})
+
+	t.Run("unknown shell", func(t *testing.T) {
+		_, _, err := runTestCommand(t, "", CompletionCommand, "powershell")
+
+		assert.ErrorIs(t, err, ErrUnknownShell)
+	})
+
+	t.Run("missing shell", func(t *testing.T) {
+		_, _, err := runTestCommand(t, "", CompletionCommand)
+
+		assert.ErrorIs(t, err, ErrUnknownShell)
+	})
+}
+
+func TestRunCompletionProfiles(t *testing.T) {
+	stdout, _, err := runTestCommandWithEnvironment(t, []string{"XDG_CONFIG_HOME=" + t.TempDir()}, "", CompletionCommand, completionProfiles)
+	require.NoError(t, err)
+	assert.Empty(t, stdout)
+
+	stdout, _, err = runTestCommandWithEnvironment(t, writeTestConfigHome(t, testConfig), "", CompletionCommand, completionProfiles)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"aws", "pin"}, strings.Fields(stdout))
+}
@@ -0,0 +1,166 @@
+package internal
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/BurntSushi/toml"
+)
+
+const ConfigFileName = "config.toml"
+
+var (
+	ErrUnknownProfile      = errors.New("Profile is not defined in the configuration file.")
+	ErrUnknownConfigOption = errors.New("Unknown option in the configuration file.")
+	ErrInvalidConfigValue  = errors.New("Invalid value in the configuration file.")
+	ErrConfigHomeNotSet    = errors.New("Neither XDG_CONFIG_HOME nor HOME is set.")
+)
+
+// Config holds option defaults read from the configuration file. Keys are the
+// long flag names, e.g. length = 24 or avoid-repeats = 3. Named profiles live
+// in [profile.<name>] tables and override the top-level defaults.
+type Config struct {
+	Path     string
+	Defaults map[string]any
+	Profiles map[string]map[string]any
+}
+
+// ConfigPath returns $XDG_CONFIG_HOME/passgen/config.toml, falling back to
+// ~/.config/passgen/config.toml, with the variables looked up in environ.
+func ConfigPath(environ []string) (string, error) {
+	configHome := getenv(environ, "XDG_CONFIG_HOME")
+	if configHome == "" {
+		home := getenv(environ, "HOME")
+		if runtime.GOOS == "windows" {
+			home = getenv(environ, "USERPROFILE")
+		}
+
+		if home == "" {
+			return "", ErrConfigHomeNotSet
+		}
+		configHome = filepath.Join(home, ".config")
+	}
+
+	return filepath.Join(configHome, ProgramName, ConfigFileName), nil
+}
+
+// LoadConfig reads the configuration file at path. A missing file is not an
+// error and results in an empty configuration.
+func LoadConfig(path string) (*Config, error) {
+	config := &Config{
+		Path:     path,
+		Defaults: map[string]any{},
+		Profiles: map[string]map[string]any{},
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return config, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	if _, err := toml.Decode(string(data), &config.Defaults); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	if profiles, ok := config.Defaults["profile"]; ok {
+		delete(config.Defaults, "profile")
+
+		tables, ok := profiles.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("%s: profile: %w", path, ErrInvalidConfigValue)
+		}
+
+		for name, table := range tables {
+			options, ok := table.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("%s: profile.%s: %w", path, name, ErrInvalidConfigValue)
+			}
+			config.Profiles[name] = options
+		}
+	}
+
+	return config, nil
+}
+
+// apply sets every option of the defaults and the selected profile on the
+// flag set, skipping flags that were given explicitly on the command line.
+func (c *Config) apply(flagSet *flag.FlagSet, profile string) error {
+	if err := c.applyOptions(flagSet, c.Defaults, ""); err != nil {
+		return err
+	}
+
+	if profile == "" {
+		return nil
+	}
+
+	options, ok := c.Profiles[profile]
+	if !ok {
+		return fmt.Errorf("%s: %w", profile, ErrUnknownProfile)
+	}
+
+	return c.applyOptions(flagSet, options, "profile."+profile+".")
+}
+
+func (c *Config) applyOptions(flagSet *flag.FlagSet, options map[string]any, prefix string) error {
+	// Sort the keys so errors are reported deterministically.
+	keys := make([]string, 0, len(options))
+	for key := range options {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		f, known := lookupOptionFlag(flagSet, key)
+		if !known || key == "profile" {
+			return fmt.Errorf("%s: %s%s: %w", c.Path, prefix, key, ErrUnknownConfigOption)
+		}
+
+		if f == nil {
+			continue
+		}
+
+		value, err := configValueString(options[key])
+		if err == nil {
+			err = f.Value.Set(value)
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %s%s: %w", c.Path, prefix, key, ErrInvalidConfigValue)
+		}
+	}
+
+	return nil
+}
+
+func visitedFlagValues(flagSet *flag.FlagSet) map[flag.Value]bool {
+	visited := map[flag.Value]bool{}
+	flagSet.Visit(func(f *flag.Flag) {
+		visited[f.Value] = true
+	})
+
+	return visited
+}
+
+func configValueString(value any) (string, error) {
+	switch value := value.(type) {
+	case string:
+		return value, nil
+	case bool:
+		return strconv.FormatBool(value), nil
+	case int64:
+		return strconv.FormatInt(value, 10), nil
+	case time.Duration:
+		return value.String(), nil
+	default:
+		return "", ErrInvalidConfigValue
+	}
+}
@@ -0,0 +1,205 @@
+package internal
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/amirhossein-fzl/passgen/pkg/passgen"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testConfig = `
+length = 16
+symbols = true
+
+[profile.aws]
+length = 24
+avoid-repeats = 3
+custom = "@#"
+
+[profile.pin]
+length = 6
+L = false
+uppercase = false
+clip-timeout = "10s"
+`
+
+func writeTestConfig(t *testing.T, contents string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), ConfigFileName)
+	require.NoError(t, os.WriteFile(path, []byte(contents), 0600))
+
+	return path
+}
+
+// writeTestConfigHome writes the configuration file into a new config home
+// and returns an environment pointing to it.
+func writeTestConfigHome(t *testing.T, contents string) []string {
+	t.Helper()
+
+	configHome := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(configHome, ProgramName), 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(configHome, ProgramName, ConfigFileName), []byte(contents), 0600))
+
+	return []string{"XDG_CONFIG_HOME=" + configHome}
+}
+
+func parseWithConfig(t *testing.T, contents string, args ...string) (*CommandLineOptions, error) {
+	t.Helper()
+
+	config, err := LoadConfig(writeTestConfig(t, contents))
+	require.NoError(t, err)
+
+	return NewCommandLineParser().WithConfig(config).Parse(args)
+}
+
+func TestConfigPath(t *testing.T) {
+	t.Run("XDG config home", func(t *testing.T) {
+		path, err := ConfigPath([]string{"HOME=/home/alice", "XDG_CONFIG_HOME=/tmp/xdg"})
+
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join("/tmp/xdg", "passgen", "config.toml"), path)
+	})
+
+	t.Run("home directory", func(t *testing.T) {
+		path, err := ConfigPath([]string{"XDG_CONFIG_HOME=", "HOME=/home/alice", "USERPROFILE=/home/alice"})
+
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join("/home/alice", ".config", "passgen", "config.toml"), path)
+	})
+
+	t.Run("process environment is not read", func(t *testing.T) {
+		t.Setenv("XDG_CONFIG_HOME", "/tmp/xdg")
+
+		_, err := ConfigPath(nil)
+
+		assert.Equal(t, ErrConfigHomeNotSet, err)
+	})
+}
+
+func TestLoadConfig(t *testing.T) {
+	t.Run("missing file", func(t *testing.T) {
+		config, err := LoadConfig(filepath.Join(t.TempDir(), "missing.toml"))
+
+		require.NoError(t, err)
+		assert.Empty(t, config.Defaults)
+		assert.Empty(t, config.Profiles)
+	})
+
+	t.Run("defaults and profiles", func(t *testing.T) {
+		config, err := LoadConfig(writeTestConfig(t, testConfig))
+
+		require.NoError(t, err)
+		assert.Equal(t, map[string]any{"length": int64(16), "symbols": true}, config.Defaults)
+		assert.Len(t, config.Profiles, 2)
+		assert.Equal(t, "@#", config.Profiles["aws"]["custom"])
+	})
+
+	t.Run("invalid TOML", func(t *testing.T) {
+		_, err := LoadConfig(writeTestConfig(t, "length = "))
+
+		assert.Error(t, err)
+	})
+
+	t.Run("profile is not a table", func(t *testing.T) {
+		_, err := LoadConfig(writeTestConfig(t, `profile = "aws"`))
+
+		assert.ErrorIs(t, err, ErrInvalidConfigValue)
+	})
+}
+
+func TestCommandLineParserParseWithConfig(t *testing.T) {
+	t.Run("applies top-level defaults", func(t *testing.T) {
+		options, err := parseWithConfig(t, testConfig)
+
+		require.NoError(t, err)
+		assert.Equal(t, 16, options.length)
+		assert.True(t, options.symbols)
+		assert.Equal(t, DefaultAvoidRepeats, options.avoidRepeats)
+	})
+
+	t.Run("profile overrides defaults", func(t *testing.T) {
+		options, err := parseWithConfig(t, testConfig, "--profile", "aws")
+
+		require.NoError(t, err)
+		assert.Equal(t, 24, options.length)
+		assert.True(t, options.symbols)
+		assert.Equal(t, 3, options.avoidRepeats)
+		assert.Equal(t, "@#", options.custom)
+	})
+
+	t.Run("short option names and durations", func(t *testing.T) {
+		options, err := parseWithConfig(t, testConfig, "--profile", "pin")
+
+		require.NoError(t, err)
+		assert.Equal(t, 6, options.length)
+		assert.False(t, options.lowercase)
+		assert.False(t, options.uppercase)
+		assert.Equal(t, 10*time.Second, options.clipTimeout)
+	})
+
+	t.Run("explicit flags win", func(t *testing.T) {
+		options, err := parseWithConfig(t, testConfig, "--profile", "aws", "-l", "30", "--symbols=false")
+
+		require.NoError(t, err)
+		assert.Equal(t, 30, options.length)
+		assert.False(t, options.symbols)
+		assert.Equal(t, 3, options.avoidRepeats)
+	})
+
+	t.Run("unknown profile", func(t *testing.T) {
+		_, err := parseWithConfig(t, testConfig, "--profile", "gcp")
+
+		assert.ErrorIs(t, err, ErrUnknownProfile)
+		assert.EqualError(t, err, "gcp: Profile is not defined in the configuration file.")
+	})
+
+	t.Run("profile without configuration file", func(t *testing.T) {
+		_, err := NewCommandLineParser().Parse([]string{"--profile", "aws"})
+
+		assert.ErrorIs(t, err, ErrUnknownProfile)
+	})
+
+	t.Run("unknown option", func(t *testing.T) {
+		_, err := parseWithConfig(t, "[profile.aws]\nlenght = 24\n", "--profile", "aws")
+
+		assert.ErrorIs(t, err, ErrUnknownConfigOption)
+		assert.ErrorContains(t, err, "profile.aws.lenght")
+	})
+
+	t.Run("insecure seed is not allowed", func(t *testing.T) {
+		_, err := parseWithConfig(t, "insecure-seed = \"debug\"\n")
+
+		assert.ErrorIs(t, err, ErrUnknownConfigOption)
+		assert.ErrorContains(t, err, "insecure-seed")
+	})
+
+	t.Run("invalid value", func(t *testing.T) {
+		_, err := parseWithConfig(t, `length = "long"`)
+
+		assert.ErrorIs(t, err, ErrInvalidConfigValue)
+		assert.ErrorContains(t, err, "length")
+	})
+
+	t.Run("invalid options are caught by validation", func(t *testing.T) {
+		options, err := parseWithConfig(t, "[profile.broken]\nmin-symbols = 2\n", "--profile", "broken")
+		require.NoError(t, err)
+
+		assert.Equal(t, passgen.ErrMinimumForDisabledCharset, options.Validate())
+	})
+}
+
+func TestLoadCommandLineWithProfile(t *testing.T) {
+	environ := writeTestConfigHome(t, testConfig)
+
+	options, err := loadCommandLine(GenerateCommand, []string{"--profile", "aws"}, environ, io.Discard)
+
+	require.NoError(t, err)
+	assert.Equal(t, 24, options.length)
+	assert.Equal(t, "@#", options.custom)
+}
@@ -0,0 +1,79 @@
+package internal
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// EnvironmentPrefix starts the environment variables of the options, the rest
+// is the long flag name in upper case, e.g. PASSGEN_LENGTH or
+// PASSGEN_AVOID_REPEATS.
+const EnvironmentPrefix = "PASSGEN_"
+
+var (
+	ErrUnknownEnvironmentVariable = errors.New("Unknown option in the environment.")
+	ErrInvalidEnvironmentValue    = errors.New("Invalid value in the environment.")
+)
+
+// EnvironmentVariable returns the name of the environment variable of a flag.
+func EnvironmentVariable(flagName string) string {
+	return EnvironmentPrefix + strings.ToUpper(strings.ReplaceAll(flagName, "-", "_"))
+}
+
+// applyEnvironment sets the options given as PASSGEN_* variables in environ,
+// formatted like os.Environ, on the flag set. The options that are set are
+// skipped by the configuration file in turn. It returns the names of the
+// variables that were used.
+func applyEnvironment(flagSet *flag.FlagSet, environ []string) ([]string, error) {
+	// Sort the variables so errors are reported deterministically.
+	environ = slices.Sorted(slices.Values(environ))
+
+	var applied []string
+	for _, variable := range environ {
+		name, value, _ := strings.Cut(variable, "=")
+		if !strings.HasPrefix(name, EnvironmentPrefix) {
+			continue
+		}
+
+		// Only long flag names have a variable, PASSGEN_L does not set -l.
+		option := strings.ToLower(strings.ReplaceAll(strings.TrimPrefix(name, EnvironmentPrefix), "_", "-"))
+		f, known := lookupOptionFlag(flagSet, option)
+		if !known || len(option) == 1 || EnvironmentVariable(option) != name {
+			return nil, fmt.Errorf("%s: %w", name, ErrUnknownEnvironmentVariable)
+		}
+
+		// Empty variables are treated as unset, so they can be cleared in CI.
+		if f == nil || value == "" {
+			continue
+		}
+
+		if err := flagSet.Set(f.Name, value); err != nil {
+			return nil, fmt.Errorf("%s: %w", name, ErrInvalidEnvironmentValue)
+		}
+		applied = append(applied, name)
+	}
+
+	return applied, nil
+}
+
+// getenv returns the value of the variable name in environ, or "" if it is not
+// set. Later entries win, like for os/exec.
+func getenv(environ []string, name string) string {
+	for _, variable := range slices.Backward(environ) {
+		if value, ok := strings.CutPrefix(variable, name+"="); ok {
+			return value
+		}
+	}
+
+	return ""
+}
+
+// withoutVariable returns environ without the variable name.
+func withoutVariable(environ []string, name string) []string {
+	return slices.DeleteFunc(slices.Clone(environ), func(variable string) bool {
+		return strings.HasPrefix(variable, name+"=")
+	})
+}
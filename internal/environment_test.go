@@ -0,0 +1,182 @@
+package internal
+
+import (
+	"io"
+	"testing"
+
+	"github.com/amirhossein-fzl/passgen/pkg/passgen"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func parseWithEnvironment(t *testing.T, environ []string, contents string, args ...string) (*CommandLineOptions, error) {
+	t.Helper()
+
+	config, err := LoadConfig(writeTestConfig(t, contents))
+	require.NoError(t, err)
+
+	return NewCommandLineParser().WithEnvironment(environ).WithConfig(config).Parse(args)
+}
+
+func TestEnvironmentVariable(t *testing.T) {
+	assert.Equal(t, "PASSGEN_LENGTH", EnvironmentVariable("length"))
+	assert.Equal(t, "PASSGEN_AVOID_REPEATS", EnvironmentVariable("avoid-repeats"))
+	assert.Equal(t, "PASSGEN_MIN_SYMBOLS", EnvironmentVariable("min-symbols"))
+}
+
+func TestGetenv(t *testing.T) {
+	environ := []string{"HOME=/root", "COLUMNS=80", "COLUMNS=120", "EMPTY="}
+
+	assert.Equal(t, "/root", getenv(environ, "HOME"))
+	assert.Equal(t, "120", getenv(environ, "COLUMNS"))
+	assert.Empty(t, getenv(environ, "EMPTY"))
+	assert.Empty(t, getenv(environ, "HOM"))
+}
+
+func TestApplyEnvironment(t *testing.T) {
+	t.Run("every password option", func(t *testing.T) {
+		options, err := parseWithEnvironment(t, []string{
+			"PASSGEN_LENGTH=20",
+			"PASSGEN_LOWERCASE=false",
+			"PASSGEN_UPPERCASE=true",
+			"PASSGEN_NUMBERS=0",
+			"PASSGEN_SYMBOLS=1",
+			"PASSGEN_CUSTOM=@#",
+			"PASSGEN_AVOID_REPEATS=2",
+			"PASSGEN_QR=true",
+			"PASSGEN_MIN_LOWERCASE=0",
+			"PASSGEN_MIN_UPPERCASE=3",
+			"PASSGEN_MIN_NUMBERS=0",
+			"PASSGEN_MIN_SYMBOLS=2",
+			"PASSGEN_MIN_CUSTOM=1",
+			"PASSGEN_EXCLUDE_AMBIGUOUS=true",
+			"PASSGEN_EXCLUDE=xyz",
+		}, "")
+
+		require.NoError(t, err)
+		assert.Equal(t, &passgen.PasswordGeneratorOptions{
+			Length:           20,
+			Lowercase:        false,
+			Uppercase:        true,
+			Numbers:          false,
+			Symbols:          true,
+			Custom:           "@#",
+			AvoidRepeats:     2,
+			MinUppercase:     3,
+			MinSymbols:       2,
+			MinCustom:        1,
+			ExcludeAmbiguous: true,
+			Exclude:          "xyz",
+		}, options.ToPasswordGeneratorOptions())
+		assert.True(t, options.qrOutput)
+	})
+
+	t.Run("flags take precedence over the environment", func(t *testing.T) {
+		options, err := parseWithEnvironment(t, []string{"PASSGEN_LENGTH=20", "PASSGEN_CUSTOM=@#"}, "", "-l", "8")
+
+		require.NoError(t, err)
+		assert.Equal(t, 8, options.length)
+		assert.Equal(t, "@#", options.custom)
+		assert.Equal(t, []string{"PASSGEN_CUSTOM"}, options.environment)
+	})
+
+	t.Run("environment takes precedence over the configuration file", func(t *testing.T) {
+		options, err := parseWithEnvironment(t, []string{"PASSGEN_LENGTH=20"}, testConfig)
+
+		require.NoError(t, err)
+		assert.Equal(t, 20, options.length)
+		assert.True(t, options.symbols)
+	})
+
+	t.Run("environment takes precedence over profiles", func(t *testing.T) {
+		options, err := parseWithEnvironment(t, []string{"PASSGEN_PROFILE=aws", "PASSGEN_AVOID_REPEATS=0"}, testConfig)
+
+		require.NoError(t, err)
+		assert.Equal(t, 24, options.length)
+		assert.Equal(t, 0, options.avoidRepeats)
+	})
+
+	t.Run("other variables and empty values are ignored", func(t *testing.T) {
+		options, err := parseWithEnvironment(t, []string{"HOME=/root", "PASSGEN_LENGTH=", "PASSGEN"}, "")
+
+		require.NoError(t, err)
+		assert.Equal(t, DefaultPasswordLength, options.length)
+		assert.Empty(t, options.environment)
+	})
+
+	t.Run("invalid value", func(t *testing.T) {
+		_, err := parseWithEnvironment(t, []string{"PASSGEN_LENGTH=long"}, "")
+
+		assert.ErrorIs(t, err, ErrInvalidEnvironmentValue)
+		assert.EqualError(t, err, "PASSGEN_LENGTH: Invalid value in the environment.")
+	})
+
+	t.Run("unknown variable", func(t *testing.T) {
+		_, err := parseWithEnvironment(t, []string{"PASSGEN_LENGHT=20"}, "")
+
+		assert.ErrorIs(t, err, ErrUnknownEnvironmentVariable)
+		assert.ErrorContains(t, err, "PASSGEN_LENGHT")
+	})
+
+	t.Run("short names have no variable", func(t *testing.T) {
+		_, err := parseWithEnvironment(t, []string{"PASSGEN_L=20"}, "")
+
+		assert.ErrorIs(t, err, ErrUnknownEnvironmentVariable)
+	})
+
+	t.Run("insecure seed is rejected", func(t *testing.T) {
+		_, err := parseWithEnvironment(t, []string{"PASSGEN_INSECURE_SEED=seed"}, "")
+
+		assert.ErrorIs(t, err, ErrUnknownEnvironmentVariable)
+	})
+
+	t.Run("phrase skips password options", func(t *testing.T) {
+		options, err := newCommandLineParser(PhraseCommand).WithEnvironment([]string{"PASSGEN_LENGTH=20", "PASSGEN_WORDS=4"}).Parse(nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, 4, options.words)
+	})
+}
+
+func TestLoadCommandLineWithEnvironment(t *testing.T) {
+	configEnviron := writeTestConfigHome(t, testConfig)
+
+	t.Run("precedence", func(t *testing.T) {
+		environ := append([]string{"PASSGEN_LENGTH=30", "PASSGEN_CUSTOM=!"}, configEnviron...)
+
+		options, err := loadCommandLine(GenerateCommand, []string{"--custom", "?"}, environ, io.Discard)
+
+		require.NoError(t, err)
+		assert.Equal(t, 30, options.length)
+		assert.Equal(t, "?", options.custom)
+		assert.True(t, options.symbols)
+		assert.True(t, options.lowercase)
+	})
+
+	t.Run("validation errors name the variable", func(t *testing.T) {
+		environ := append([]string{"PASSGEN_LENGTH=16", "PASSGEN_MIN_NUMBERS=20"}, configEnviron...)
+
+		_, err := loadCommandLine(GenerateCommand, nil, environ, io.Discard)
+
+		assert.ErrorIs(t, err, passgen.ErrMinimumsExceedLength)
+		assert.ErrorContains(t, err, "PASSGEN_MIN_NUMBERS: ")
+	})
+
+	t.Run("validation errors caused by flags are not blamed on the environment", func(t *testing.T) {
+		environ := append([]string{"PASSGEN_SYMBOLS=true"}, configEnviron...)
+
+		_, err := loadCommandLine(GenerateCommand, []string{"-l", "0"}, environ, io.Discard)
+
+		assert.Equal(t, passgen.ErrLengthMustBeGreaterThanZero, err)
+	})
+
+	t.Run("the process environment is not read", func(t *testing.T) {
+		t.Setenv("PASSGEN_LENGTH", "30")
+		t.Setenv("PASSGEN_UNKNOWN", "x")
+
+		options, err := loadCommandLine(GenerateCommand, nil, configEnviron, io.Discard)
+
+		require.NoError(t, err)
+		assert.Equal(t, 16, options.length)
+	})
+}
@@ -0,0 +1,231 @@
+package internal
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// flagDefinition describes an option of a command. Flag sets, help, the man
+// page, the markdown reference and the shell completions are all built from
+// these definitions, so a new option only has to be added here.
+type flagDefinition struct {
+	short string
+	long  string
+	// arg names the value in help, boolean flags take none.
+	arg   string
+	usage string
+	// value points to the option and holds its default, it is one of *bool,
+	// *int, *string or *time.Duration. Flags handled by the flag package
+	// itself, like --help, have none.
+	value any
+}
+
+var helpFlag = flagDefinition{"h", "help", "", "Display the help message", nil}
+
+// globalFlags are only shown in the command list, runCommand handles them.
+var globalFlags = []flagDefinition{
+	helpFlag,
+	{"v", "version", "", "Print the version", nil},
+}
+
+func passwordFlags(o *CommandLineOptions) []flagDefinition {
+	return []flagDefinition{
+		{"l", "length", "length", "Password length", &o.length},
+		{"L", "lowercase", "", "Include lowercase letters (a-z)", &o.lowercase},
+		{"U", "uppercase", "", "Include uppercase letters (A-Z)", &o.uppercase},
+		{"N", "numbers", "", "Include numbers (0-9)", &o.numbers},
+		{"S", "symbols", "", "Include symbols (!@#$%^&* etc.)", &o.symbols},
+		{"C", "custom", "custom", "Custom character set to use", &o.custom},
+		{"a", "avoid-repeats", "count", "Number of last characters that shouldn't repeat", &o.avoidRepeats},
+		{"", "min-lowercase", "count", "Minimum number of lowercase letters", &o.minLowercase},
+		{"", "min-uppercase", "count", "Minimum number of uppercase letters", &o.minUppercase},
+		{"", "min-numbers", "count", "Minimum number of numbers", &o.minNumbers},
+		{"", "min-symbols", "count", "Minimum number of symbols", &o.minSymbols},
+		{"", "min-custom", "count", "Minimum number of custom characters", &o.minCustom},
+		{"", "exclude-ambiguous", "", "Exclude look-alike characters (l1I|O0)", &o.excludeAmbiguous},
+		{"", "exclude", "characters", "Characters that must never be used", &o.exclude},
+		{"", "policy", "path", "Generate passwords satisfying a JSON policy file", &o.policyPath},
+		{"m", "mode", "mode", "Generation mode: random, pronounceable or totp", &o.mode},
+		{"", "digits", "digits", "Number of digits in a pronounceable password", &o.digits},
+		{"", "totp-bytes", "bytes", "TOTP secret length in bytes", &o.totpBytes},
+		{"", "totp-algorithm", "algorithm", "TOTP algorithm: SHA1, SHA256 or SHA512", &o.totpAlgorithm},
+		{"", "totp-digits", "digits", "Number of digits of TOTP codes", &o.totpDigits},
+		{"", "totp-period", "seconds", "TOTP code validity period in seconds", &o.totpPeriod},
+		{"", "totp-issuer", "issuer", "Issuer shown in authenticator apps", &o.totpIssuer},
+		{"", "totp-account", "account", "Account name shown in authenticator apps", &o.totpAccount},
+	}
+}
+
+func passphraseFlags(o *CommandLineOptions) []flagDefinition {
+	return []flagDefinition{
+		{"w", "words", "words", "Generate a passphrase with this many words", &o.words},
+		{"", "separator", "separator", "Separator between passphrase words", &o.separator},
+		{"", "capitalize", "", "Capitalize passphrase words or one pronounceable letter", &o.capitalize},
+		{"", "with-number", "", "Append a number to a random passphrase word", &o.withNumber},
+		{"", "with-symbol", "", "Append a symbol to a random passphrase word", &o.withSymbol},
+	}
+}
+
+// commonFlags are the output options shared by generate and phrase.
+func commonFlags(o *CommandLineOptions) []flagDefinition {
+	return []flagDefinition{
+		{"", "show-entropy", "", "Print the estimated entropy and crack time", &o.showEntropy},
+		{"n", "count", "count", "Number of passwords to generate, one per line", &o.count},
+		{"", "unique", "", "Never repeat a password within the batch", &o.unique},
+		{"f", "format", "format", "Output format: text, json or csv", &o.format},
+		{"", "clip", "", "Copy the password to the clipboard instead of printing it", &o.clip},
+		{"", "clip-backend", "backend", "Clipboard: auto, xclip, xsel, wl-copy, pbcopy, clip or osc52", &o.clipBackend},
+		{"", "clip-timeout", "duration", "Clear the clipboard after this long, 0 keeps it", &o.clipTimeout},
+		{"q", "qr", "", "Generate QR code output in ANSI UTF-8 format", &o.qrOutput},
+		{"", "qr-png", "path", "Write the QR code to a PNG file", &o.qrPng},
+		{"", "qr-svg", "path", "Write the QR code to an SVG file", &o.qrSvg},
+		{"", "qr-size", "pixels", "QR code image size in pixels", &o.qrSize},
+		{"", "qr-level", "level", "QR error correction: low, medium, high or highest", &o.qrLevel},
+		{"", "qr-margin", "modules", "QR code margin in modules", &o.qrMargin},
+		{"", "qr-style", "style", "QR rendering: ansi, inverted, utf8 or ascii", &o.qrStyle},
+		{"", "wifi-ssid", "ssid", "Encode the password as a Wi-Fi network join QR code", &o.wifiSsid},
+		{"", "wifi-security", "security", "Wi-Fi security: WPA, WEP or SAE", &o.wifiSecurity},
+		{"", "wifi-hidden", "", "Mark the Wi-Fi network as hidden", &o.wifiHidden},
+		{"", "profile", "profile", "Use a profile from the configuration file", &o.profile},
+		{"", "insecure-seed", "seed", "Debugging only: derive all output from seed, NOT secure", &o.insecureSeed},
+	}
+}
+
+func generateFlags(o *CommandLineOptions) []flagDefinition {
+	return slices.Concat([]flagDefinition{helpFlag}, passwordFlags(o), passphraseFlags(o), commonFlags(o))
+}
+
+func phraseFlags(o *CommandLineOptions) []flagDefinition {
+	return slices.Concat([]flagDefinition{helpFlag}, passphraseFlags(o), commonFlags(o))
+}
+
+func checkFlags(o *CommandLineOptions) []flagDefinition {
+	return []flagDefinition{
+		helpFlag,
+		{"f", "format", "format", "Output format: text or json", &o.format},
+	}
+}
+
+func totpCodeFlags(o *CommandLineOptions) []flagDefinition {
+	flags := []flagDefinition{helpFlag}
+	for _, f := range passwordFlags(o) {
+		if f.long == "totp-algorithm" || f.long == "totp-digits" || f.long == "totp-period" {
+			flags = append(flags, f)
+		}
+	}
+
+	return flags
+}
+
+func helpOnlyFlags(o *CommandLineOptions) []flagDefinition {
+	return []flagDefinition{helpFlag}
+}
+
+// defineFlags registers the definitions on the flag set. The short and long
+// names of a definition share the same value.
+func defineFlags(flagSet *flag.FlagSet, flags []flagDefinition) {
+	for _, f := range flags {
+		for _, name := range f.names() {
+			switch value := f.value.(type) {
+			case *bool:
+				flagSet.BoolVar(value, name, *value, f.usage)
+			case *int:
+				flagSet.IntVar(value, name, *value, f.usage)
+			case *string:
+				flagSet.StringVar(value, name, *value, f.usage)
+			case *time.Duration:
+				flagSet.DurationVar(value, name, *value, f.usage)
+			}
+		}
+	}
+}
+
+// newFlagSet returns the flag set of a command with the flags registered.
+func newFlagSet(command string, flags []flagDefinition, stderr io.Writer) *flag.FlagSet {
+	flagSet := flag.NewFlagSet(ProgramName+" "+command, flag.ContinueOnError)
+	flagSet.SetOutput(stderr)
+	defineFlags(flagSet, flags)
+
+	return flagSet
+}
+
+func (f flagDefinition) names() []string {
+	if f.short == "" {
+		return []string{f.long}
+	}
+
+	return []string{f.short, f.long}
+}
+
+// defaultValue formats the default of the flag, empty when it has none.
+func (f flagDefinition) defaultValue() string {
+	switch value := f.value.(type) {
+	case *bool:
+		return strconv.FormatBool(*value)
+	case *int:
+		return strconv.Itoa(*value)
+	case *string:
+		return *value
+	case *time.Duration:
+		return value.String()
+	}
+
+	return ""
+}
+
+// hasDefault reports whether the default is worth showing, zero values like
+// false or 0 are left out.
+func (f flagDefinition) hasDefault() bool {
+	switch f.defaultValue() {
+	case "", "false", "0", "0s":
+		return false
+	}
+
+	return true
+}
+
+func (f flagDefinition) synopsis() string {
+	var s strings.Builder
+
+	if f.short != "" {
+		fmt.Fprintf(&s, "-%s, ", f.short)
+	} else {
+		s.WriteString("    ")
+	}
+
+	fmt.Fprintf(&s, "--%s", f.long)
+	if f.arg != "" {
+		fmt.Fprintf(&s, " <%s>", f.arg)
+	}
+
+	return s.String()
+}
+
+func printFlags(w io.Writer, flags []flagDefinition) {
+	width := 0
+	for _, f := range flags {
+		width = max(width, len(f.synopsis()))
+	}
+
+	for _, f := range flags {
+		fmt.Fprintf(w, "  %-*s  %s", width, f.synopsis(), f.usage)
+		if f.hasDefault() {
+			fmt.Fprintf(w, " (default: %s)", f.defaultValue())
+		}
+		fmt.Fprintln(w)
+	}
+}
+
+// commandFlags returns the flags of a command bound to options.
+func commandFlags(command string, options *CommandLineOptions) []flagDefinition {
+	if c, ok := lookupCommand(command); ok {
+		return c.Flags(options)
+	}
+
+	return nil
+}
@@ -0,0 +1,223 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+)
+
+const ManCommand = "man"
+
+const (
+	FormatMan      = "man"
+	FormatMarkdown = "markdown"
+)
+
+var ErrUnknownManFormat = errors.New("Manual format must be one of man or markdown.")
+
+func manFlags(o *CommandLineOptions) []flagDefinition {
+	return []flagDefinition{
+		helpFlag,
+		{"f", "format", "format", "Output format: man or markdown", &o.format},
+	}
+}
+
+func runMan(ctx context.Context, args, environ []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	options := newCommandLineOptions(ManCommand)
+	flags := manFlags(options)
+	flagSet := newFlagSet(ManCommand, flags, stderr)
+	flagSet.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: %s %s [options]\n\n", ProgramName, ManCommand)
+		fmt.Fprintf(stderr, "Print the manual page, or a markdown reference of the options of every command.\n\n")
+		fmt.Fprintf(stderr, "Options:\n")
+		printFlags(stderr, flags)
+		fmt.Fprintf(stderr, "\nExamples:\n")
+		fmt.Fprintf(stderr, "  %s %s > /usr/local/share/man/man1/%s.1\n", ProgramName, ManCommand, ProgramName)
+		fmt.Fprintf(stderr, "  %s %s --format markdown\n", ProgramName, ManCommand)
+	}
+
+	if err := flagSet.Parse(args); err != nil {
+		return usage(err)
+	}
+
+	if flagSet.NArg() > 0 {
+		return usage(fmt.Errorf("%s: %w", flagSet.Arg(0), ErrUnknownCommand))
+	}
+
+	switch options.format {
+	case FormatMan:
+		writeManPage(stdout, Commands())
+	case FormatMarkdown:
+		writeMarkdownReference(stdout, Commands())
+	default:
+		return usage(ErrUnknownManFormat)
+	}
+
+	return nil
+}
+
+var roffEscaper = strings.NewReplacer(`\`, `\e`, `-`, `\-`)
+
+// roff escapes text for the man page. Lines starting with a dot or a quote
+// would be taken for requests.
+func roff(text string) string {
+	text = roffEscaper.Replace(text)
+	if strings.HasPrefix(text, ".") || strings.HasPrefix(text, "'") {
+		text = `\&` + text
+	}
+
+	return text
+}
+
+func roffFlag(f flagDefinition) string {
+	var s strings.Builder
+
+	if f.short != "" {
+		fmt.Fprintf(&s, `\fB%s\fR, `, roff(dashed(f.short)))
+	}
+
+	fmt.Fprintf(&s, `\fB%s\fR`, roff("--"+f.long))
+	if f.arg != "" {
+		fmt.Fprintf(&s, ` \fI%s\fR`, roff(f.arg))
+	}
+
+	return s.String()
+}
+
+func writeManFlags(w io.Writer, flags []flagDefinition) {
+	for _, f := range flags {
+		fmt.Fprintf(w, ".TP\n%s\n%s", roffFlag(f), roff(f.usage))
+		if f.hasDefault() {
+			fmt.Fprintf(w, " (default: %s)", roff(f.defaultValue()))
+		}
+		fmt.Fprintln(w, ".")
+	}
+}
+
+func writeManPage(w io.Writer, commands []Command) {
+	date := Date
+	if dateTime, err := time.Parse(time.RFC3339, Date); err == nil {
+		date = dateTime.Format(time.DateOnly)
+	}
+
+	fmt.Fprintf(w, ".TH %s 1 \"%s\" \"%s %s\" \"User Commands\"\n", strings.ToUpper(ProgramName), date, ProgramName, roff(Version))
+
+	fmt.Fprintf(w, ".SH NAME\n")
+	fmt.Fprintf(w, "%s \\- a secure password generator with customizable options\n", ProgramName)
+
+	fmt.Fprintf(w, ".SH SYNOPSIS\n")
+	fmt.Fprintf(w, ".B %s\n[\\fIcommand\\fR] [\\fIoptions\\fR]\n", ProgramName)
+
+	fmt.Fprintf(w, ".SH DESCRIPTION\n")
+	fmt.Fprintf(w, "Generates passwords, passphrases and TOTP secrets from a cryptographically secure random source.\n")
+	fmt.Fprintf(w, "Without a command the options are passed to \\fB%s\\fR.\n", GenerateCommand)
+
+	fmt.Fprintf(w, ".SH OPTIONS\n")
+	writeManFlags(w, globalFlags)
+
+	fmt.Fprintf(w, ".SH COMMANDS\n")
+	for _, command := range commands {
+		fmt.Fprintf(w, ".SS %s\n", command.Name)
+		fmt.Fprintf(w, "%s.\n", roff(command.Summary))
+		writeManFlags(w, slices.DeleteFunc(command.flagDefinitions(), func(f flagDefinition) bool {
+			return f.value == nil
+		}))
+	}
+
+	fmt.Fprintf(w, ".SH EXAMPLES\n")
+	fmt.Fprintf(w, ".nf\n")
+	for _, example := range slices.Concat(commandExamples(GenerateCommand), commandExamples(PhraseCommand)) {
+		fmt.Fprintf(w, "%s\n", roff(example))
+	}
+	fmt.Fprintf(w, ".fi\n")
+
+	fmt.Fprintf(w, ".SH ENVIRONMENT\n")
+	fmt.Fprintf(w, ".TP\n.B %s\\fIOPTION\\fR\n", roff(EnvironmentPrefix))
+	fmt.Fprintf(w, "Sets an option of %s and %s, \\fIOPTION\\fR is the long name in upper case with underscores for dashes, e.g. \\fB%s=16\\fR.\n",
+		GenerateCommand, PhraseCommand, roff(EnvironmentVariable("length")))
+	fmt.Fprintf(w, "Options given on the command line take precedence, and the configuration file is only used for options set by neither.\n")
+	fmt.Fprintf(w, "Empty variables are ignored, and \\-\\-insecure\\-seed cannot be set this way.\n")
+	fmt.Fprintf(w, ".TP\n.B XDG_CONFIG_HOME\n")
+	fmt.Fprintf(w, "Directory of the configuration file.\n")
+
+	fmt.Fprintf(w, ".SH FILES\n")
+	fmt.Fprintf(w, ".TP\n.I $XDG_CONFIG_HOME/%s/%s\n", ProgramName, ConfigFileName)
+	fmt.Fprintf(w, "Option defaults and named profiles, \\fI~/.config/%s/%s\\fR when XDG_CONFIG_HOME is not set.\n", ProgramName, ConfigFileName)
+
+	fmt.Fprintf(w, ".SH EXIT STATUS\n")
+	fmt.Fprintf(w, ".TP\n.B %d\nSuccess.\n", ExitOK)
+	fmt.Fprintf(w, ".TP\n.B %d\nInvalid options or input.\n", ExitUsage)
+	fmt.Fprintf(w, ".TP\n.B %d\nGenerating or writing the output failed.\n", ExitIOError)
+}
+
+var markdownEscaper = strings.NewReplacer(`|`, `\|`)
+
+// writeMarkdownReference writes a table of the options of every command that
+// has any. The options section of the README is generated with it.
+func writeMarkdownReference(w io.Writer, commands []Command) {
+	first := true
+
+	for _, command := range commands {
+		var rows [][4]string
+		for _, f := range command.flagDefinitions() {
+			if f.value == nil {
+				continue
+			}
+
+			short := ""
+			if f.short != "" {
+				short = "`-" + f.short + "`"
+			}
+
+			value := f.defaultValue()
+			if value == "" {
+				value = `""`
+			}
+
+			rows = append(rows, [4]string{short, "`--" + f.long + "`", markdownEscaper.Replace(f.usage), "`" + value + "`"})
+		}
+
+		if len(rows) == 0 {
+			continue
+		}
+
+		if !first {
+			fmt.Fprintln(w)
+		}
+		first = false
+
+		fmt.Fprintf(w, "#### `%s %s`\n\n", ProgramName, command.Name)
+		writeMarkdownTable(w, [4]string{"Short", "Long", "Description", "Default"}, rows)
+	}
+}
+
+func writeMarkdownTable(w io.Writer, header [4]string, rows [][4]string) {
+	var widths [4]int
+	for _, row := range append([][4]string{header}, rows...) {
+		for i, cell := range row {
+			widths[i] = max(widths[i], len(cell))
+		}
+	}
+
+	writeRow := func(row [4]string) {
+		for i, cell := range row {
+			fmt.Fprintf(w, "| %-*s ", widths[i], cell)
+		}
+		fmt.Fprintln(w, "|")
+	}
+
+	writeRow(header)
+	var separator [4]string
+	for i, width := range widths {
+		separator[i] = strings.Repeat("-", width)
+	}
+	writeRow(separator)
+
+	for _, row := range rows {
+		writeRow(row)
+	}
+}
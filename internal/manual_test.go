@@ -0,0 +1,66 @@
+package internal
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunMan(t *testing.T) {
+	t.Run("man page", func(t *testing.T) {
+		stdout, _, err := runTestCommand(t, "", ManCommand)
+		require.NoError(t, err)
+
+		assert.True(t, strings.HasPrefix(stdout, ".TH PASSGEN 1 "))
+		assert.Contains(t, stdout, ".SS phrase\n")
+		assert.Contains(t, stdout, "\\fB\\-l\\fR, \\fB\\-\\-length\\fR \\fIlength\\fR\nPassword length (default: 12).\n")
+		assert.Contains(t, stdout, "\\fB\\-w\\fR, \\fB\\-\\-words\\fR \\fIwords\\fR\nGenerate a passphrase with this many words (default: 6).\n")
+		assert.NotContains(t, stdout, "\n-")
+	})
+
+	t.Run("markdown reference", func(t *testing.T) {
+		stdout, _, err := runTestCommand(t, "", ManCommand, "--format", FormatMarkdown)
+		require.NoError(t, err)
+
+		assert.Contains(t, stdout, "#### `passgen totp-code`\n")
+		assert.Regexp(t, "\\| `-a` +\\| `--avoid-repeats` +\\| Number of last characters that shouldn't repeat +\\| `1` +\\|", stdout)
+		assert.Contains(t, stdout, `(l1I\|O0)`)
+		assert.NotContains(t, stdout, "--help")
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		_, _, err := runTestCommand(t, "", ManCommand, "-f", "html")
+
+		assert.ErrorIs(t, err, ErrUnknownManFormat)
+		assert.True(t, isUsageError(err))
+	})
+}
+
+func TestReadmeOptionsAreGenerated(t *testing.T) {
+	readme, err := os.ReadFile("../README.md")
+	require.NoError(t, err)
+
+	_, options, found := strings.Cut(string(readme), "<!-- options:begin -->\n")
+	require.True(t, found)
+	options, _, found = strings.Cut(options, "<!-- options:end -->")
+	require.True(t, found)
+
+	var reference bytes.Buffer
+	writeMarkdownReference(&reference, Commands())
+
+	assert.Equal(t, reference.String(), options, "run 'passgen man --format markdown' and update the options of README.md")
+}
+
+func TestHelpShowsDefaultsOfTheFlags(t *testing.T) {
+	var help bytes.Buffer
+	printFlags(&help, generateFlags(newCommandLineOptions(GenerateCommand)))
+
+	assert.Regexp(t, `-l, --length <length> +Password length \(default: 12\)\n`, help.String())
+	assert.Regexp(t, `--clip-timeout <duration> +Clear the clipboard after this long, 0 keeps it \(default: 45s\)\n`, help.String())
+	assert.Regexp(t, `-S, --symbols +Include symbols \(!@#\$%\^&\* etc\.\)\n`, help.String())
+	assert.Regexp(t, `--totp-account <account> +Account name shown in authenticator apps\n`, help.String())
+}
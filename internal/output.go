@@ -0,0 +1,318 @@
+package internal
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/amirhossein-fzl/passgen/pkg/passgen"
+)
+
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+	FormatCSV  = "csv"
+)
+
+type PasswordResult struct {
+	Password  string   `json:"password"`
+	Length    int      `json:"length"`
+	Classes   []string `json:"classes"`
+	Entropy   float64  `json:"entropy"`
+	CrackTime string   `json:"crack_time"`
+	URI       string   `json:"uri,omitempty"`
+	Options   any      `json:"options"`
+}
+
+func (c *CommandLineOptions) Results(passwords []string) ([]PasswordResult, error) {
+	entropy, err := c.Entropy()
+	if err != nil {
+		return nil, err
+	}
+
+	var options any = c.ToPasswordGeneratorOptions()
+	if c.IsPassphrase() {
+		options = c.ToPassphraseGeneratorOptions()
+	} else if c.IsPronounceable() {
+		options = c.ToPronounceableGeneratorOptions()
+	} else if c.IsTotp() {
+		options = c.ToTotpGeneratorOptions()
+	}
+
+	results := make([]PasswordResult, 0, len(passwords))
+	for _, password := range passwords {
+		results = append(results, PasswordResult{
+			Password:  password,
+			Length:    utf8.RuneCountInString(password),
+			Classes:   c.Classes(),
+			Entropy:   math.Round(entropy.Bits*100) / 100,
+			CrackTime: entropy.CrackTime(),
+			URI:       c.totpURI(password),
+			Options:   options,
+		})
+	}
+
+	return results, nil
+}
+
+func (c *CommandLineOptions) Classes() []string {
+	classes := []string{}
+
+	if c.IsPassphrase() {
+		classes = append(classes, "words")
+		if c.withNumber {
+			classes = append(classes, "numbers")
+		}
+		if c.withSymbol {
+			classes = append(classes, "symbols")
+		}
+
+		return classes
+	}
+
+	if c.IsPronounceable() {
+		classes = append(classes, "lowercase")
+		if c.capitalize {
+			classes = append(classes, "uppercase")
+		}
+		if c.digits > 0 {
+			classes = append(classes, "numbers")
+		}
+
+		return classes
+	}
+
+	if c.IsTotp() {
+		return append(classes, "uppercase", "numbers")
+	}
+
+	options := c.ToPasswordGeneratorOptions()
+	for _, class := range []struct {
+		name    string
+		enabled bool
+	}{
+		{"uppercase", options.Uppercase},
+		{"lowercase", options.Lowercase},
+		{"numbers", options.Numbers},
+		{"symbols", options.Symbols},
+		{"custom", options.Custom != ""},
+	} {
+		if class.enabled {
+			classes = append(classes, class.name)
+		}
+	}
+
+	return classes
+}
+
+// WriteOutput prints the generated passwords in the selected format. In JSON
+// and CSV mode QR codes go to stderr so stdout stays machine readable.
+func (c *CommandLineOptions) WriteOutput(stdout, stderr io.Writer, passwords []string) error {
+	results, err := c.Results(passwords)
+	if err != nil {
+		return err
+	}
+
+	if err := c.writeQrFiles(passwords); err != nil {
+		return err
+	}
+
+	switch c.format {
+	case FormatJSON:
+		if err := c.writeQrCodes(stderr, passwords); err != nil {
+			return err
+		}
+		return writeJSON(stdout, results)
+	case FormatCSV:
+		if err := c.writeQrCodes(stderr, passwords); err != nil {
+			return err
+		}
+		return writeCSV(stdout, results, c.IsTotp())
+	default:
+		return c.writeText(stdout, results)
+	}
+}
+
+func (c *CommandLineOptions) writeText(w io.Writer, results []PasswordResult) error {
+	for _, result := range results {
+		if c.qrOutput {
+			if err := c.writeQrCodes(w, []string{result.Password}); err != nil {
+				return err
+			}
+		}
+
+		// The password goes to the clipboard instead of the scrollback.
+		if c.clip {
+			continue
+		}
+
+		if c.qrOutput {
+			fmt.Fprint(w, "Password: ")
+		}
+
+		fmt.Fprintln(w, result.Password)
+
+		if result.URI != "" {
+			fmt.Fprintf(w, "URI: %s\n", result.URI)
+		}
+	}
+
+	if c.showEntropy && len(results) > 0 {
+		fmt.Fprintf(w, "Entropy: %.2f bits (estimated crack time: %s)\n", results[0].Entropy, results[0].CrackTime)
+	}
+
+	return nil
+}
+
+func (c *CommandLineOptions) writeQrCodes(w io.Writer, passwords []string) error {
+	if !c.qrOutput {
+		return nil
+	}
+
+	level, err := passgen.ParseQrLevel(c.qrLevel)
+	if err != nil {
+		return err
+	}
+
+	style, err := passgen.ParseQrStyle(c.qrStyle)
+	if err != nil {
+		return err
+	}
+
+	// Fall back to lower error correction levels rather than printing a code
+	// that wraps around and cannot be scanned.
+	width := TerminalWidth(w, c.environ)
+	if style == passgen.QrStyleASCII && width > 0 {
+		width = max(width/2, 1)
+	}
+
+	for _, password := range passwords {
+		content, err := c.qrContent(password)
+		if err != nil {
+			return err
+		}
+
+		qrcode, err := passgen.NewQrCodeFitting(content, c.qrMargin, level, width)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintln(w, qrcode.Render(style))
+	}
+
+	return nil
+}
+
+func (c *CommandLineOptions) writeQrFiles(passwords []string) error {
+	if c.qrPng == "" && c.qrSvg == "" {
+		return nil
+	}
+
+	if len(passwords) != 1 {
+		return ErrQrFileWithCount
+	}
+
+	level, err := passgen.ParseQrLevel(c.qrLevel)
+	if err != nil {
+		return err
+	}
+
+	content, err := c.qrContent(passwords[0])
+	if err != nil {
+		return err
+	}
+
+	qrcode, err := passgen.NewQrCodeWithLevel(content, c.qrMargin, level)
+	if err != nil {
+		return err
+	}
+
+	if c.qrPng != "" {
+		if err := qrcode.WritePNG(c.qrPng, c.qrSize); err != nil {
+			return err
+		}
+	}
+
+	if c.qrSvg != "" {
+		if err := qrcode.WriteSVG(c.qrSvg, c.qrSize); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// qrContent returns the text to encode for a password, which is either the
+// password itself, a Wi-Fi network join payload or a TOTP key URI.
+func (c *CommandLineOptions) qrContent(password string) (string, error) {
+	if c.IsTotp() {
+		return c.totpURI(password), nil
+	}
+
+	if !c.IsWifi() {
+		return password, nil
+	}
+
+	return c.ToWifiCredentials(password).Payload()
+}
+
+func (c *CommandLineOptions) totpURI(secret string) string {
+	if !c.IsTotp() {
+		return ""
+	}
+
+	return passgen.TotpURI(secret, *c.ToTotpGeneratorOptions())
+}
+
+func writeJSON(w io.Writer, results []PasswordResult) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+
+	for _, result := range results {
+		if err := encoder.Encode(result); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeCSV writes a header and a row per result. The uri column is only added
+// for TOTP secrets, like the uri field of the JSON output.
+func writeCSV(w io.Writer, results []PasswordResult, withURI bool) error {
+	writer := csv.NewWriter(w)
+
+	header := []string{"password", "length", "classes", "entropy", "crack_time"}
+	if withURI {
+		header = append(header, "uri")
+	}
+
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	for _, result := range results {
+		record := []string{
+			result.Password,
+			strconv.Itoa(result.Length),
+			strings.Join(result.Classes, " "),
+			strconv.FormatFloat(result.Entropy, 'f', 2, 64),
+			result.CrackTime,
+		}
+		if withURI {
+			record = append(record, result.URI)
+		}
+
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,237 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"math"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/amirhossein-fzl/passgen/pkg/passgen"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommandLineOptionsClasses(t *testing.T) {
+	tests := []struct {
+		name     string
+		options  *CommandLineOptions
+		expected []string
+	}{
+		{
+			name:     "default classes",
+			options:  &CommandLineOptions{lowercase: true, uppercase: true, numbers: true},
+			expected: []string{"uppercase", "lowercase", "numbers"},
+		},
+		{
+			name:     "symbols and custom",
+			options:  &CommandLineOptions{symbols: true, custom: "abc"},
+			expected: []string{"symbols", "custom"},
+		},
+		{
+			name:     "pronounceable",
+			options:  &CommandLineOptions{mode: ModePronounceable, capitalize: true, digits: 2, symbols: true},
+			expected: []string{"lowercase", "uppercase", "numbers"},
+		},
+		{
+			name:     "passphrase",
+			options:  &CommandLineOptions{words: 4, withNumber: true, lowercase: true},
+			expected: []string{"words", "numbers"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.options.Classes())
+		})
+	}
+}
+
+func TestCommandLineOptionsResults(t *testing.T) {
+	options := &CommandLineOptions{length: 4, numbers: true}
+
+	results, err := options.Results([]string{"1234", "5678"})
+
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, "1234", results[0].Password)
+	assert.Equal(t, 4, results[0].Length)
+	assert.Equal(t, []string{"numbers"}, results[0].Classes)
+	assert.InDelta(t, 4*math.Log2(10), results[0].Entropy, 0.005)
+	assert.Equal(t, 13.29, results[0].Entropy)
+	assert.Equal(t, "5678", results[1].Password)
+}
+
+func TestCommandLineOptionsWriteOutputText(t *testing.T) {
+	t.Run("prints one password per line", func(t *testing.T) {
+		options := &CommandLineOptions{length: 4, numbers: true, format: FormatText}
+		var stdout, stderr bytes.Buffer
+
+		err := options.WriteOutput(&stdout, &stderr, []string{"1234", "5678"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "1234\n5678\n", stdout.String())
+		assert.Empty(t, stderr.String())
+	})
+
+	t.Run("prints entropy and QR code", func(t *testing.T) {
+		options := &CommandLineOptions{length: 4, numbers: true, format: FormatText, qrOutput: true, qrLevel: DefaultQrLevel, qrStyle: DefaultQrStyle, showEntropy: true}
+		var stdout, stderr bytes.Buffer
+
+		err := options.WriteOutput(&stdout, &stderr, []string{"1234"})
+
+		require.NoError(t, err)
+		assert.Contains(t, stdout.String(), "\033[40;37;1m")
+		assert.Contains(t, stdout.String(), "Password: 1234\n")
+		assert.Contains(t, stdout.String(), "Entropy: 13.29 bits")
+	})
+}
+
+func TestCommandLineOptionsWriteOutputTotp(t *testing.T) {
+	options := &CommandLineOptions{mode: ModeTotp, format: FormatText, totpBytes: 20, totpAlgorithm: "SHA1", totpDigits: 6, totpPeriod: 30, totpIssuer: "ACME", totpAccount: "ci"}
+	var stdout, stderr bytes.Buffer
+
+	err := options.WriteOutput(&stdout, &stderr, []string{"JBSWY3DPEHPK3PXP"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "JBSWY3DPEHPK3PXP\nURI: otpauth://totp/ACME:ci?algorithm=SHA1&digits=6&issuer=ACME&period=30&secret=JBSWY3DPEHPK3PXP\n", stdout.String())
+
+	content, err := options.qrContent("JBSWY3DPEHPK3PXP")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(content, "otpauth://totp/"))
+}
+
+func TestCommandLineOptionsWriteOutputJSON(t *testing.T) {
+	options := &CommandLineOptions{length: 4, numbers: true, format: FormatJSON, qrOutput: true, qrLevel: DefaultQrLevel, qrStyle: DefaultQrStyle}
+	var stdout, stderr bytes.Buffer
+
+	err := options.WriteOutput(&stdout, &stderr, []string{"1234", "5678"})
+
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	require.Len(t, lines, 2)
+
+	var result map[string]any
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &result))
+	assert.Equal(t, "1234", result["password"])
+	assert.Equal(t, float64(4), result["length"])
+	assert.Equal(t, []any{"numbers"}, result["classes"])
+	assert.Equal(t, 13.29, result["entropy"])
+	assert.Equal(t, float64(4), result["options"].(map[string]any)["length"])
+
+	assert.Contains(t, stderr.String(), "\033[40;37;1m")
+	assert.NotContains(t, stdout.String(), "\033[")
+}
+
+func TestCommandLineOptionsWriteOutputCSV(t *testing.T) {
+	options := &CommandLineOptions{length: 4, numbers: true, custom: ",\"", format: FormatCSV}
+	var stdout, stderr bytes.Buffer
+
+	err := options.WriteOutput(&stdout, &stderr, []string{"1,\"2"})
+
+	require.NoError(t, err)
+	records, err := csv.NewReader(&stdout).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, []string{"password", "length", "classes", "entropy", "crack_time"}, records[0])
+	assert.Equal(t, "1,\"2", records[1][0])
+	assert.Equal(t, "4", records[1][1])
+	assert.Equal(t, "numbers custom", records[1][2])
+}
+
+func TestCommandLineOptionsWriteOutputCSVTotp(t *testing.T) {
+	options := &CommandLineOptions{mode: ModeTotp, format: FormatCSV, totpBytes: 20, totpAlgorithm: "SHA1", totpDigits: 6, totpPeriod: 30, totpIssuer: "ACME", totpAccount: "ci"}
+	var stdout, stderr bytes.Buffer
+
+	err := options.WriteOutput(&stdout, &stderr, []string{"JBSWY3DPEHPK3PXP"})
+
+	require.NoError(t, err)
+	records, err := csv.NewReader(&stdout).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, []string{"password", "length", "classes", "entropy", "crack_time", "uri"}, records[0])
+	assert.Equal(t, "otpauth://totp/ACME:ci?algorithm=SHA1&digits=6&issuer=ACME&period=30&secret=JBSWY3DPEHPK3PXP", records[1][5])
+}
+
+func TestCommandLineOptionsWriteOutputQrFiles(t *testing.T) {
+	dir := t.TempDir()
+	options := &CommandLineOptions{
+		length:  4,
+		numbers: true,
+		format:  FormatText,
+		qrPng:   filepath.Join(dir, "qr.png"),
+		qrSvg:   filepath.Join(dir, "qr.svg"),
+		qrSize:  64,
+		qrLevel: DefaultQrLevel,
+		qrStyle: DefaultQrStyle,
+	}
+	var stdout, stderr bytes.Buffer
+
+	err := options.WriteOutput(&stdout, &stderr, []string{"1234"})
+
+	require.NoError(t, err)
+	assert.FileExists(t, options.qrPng)
+	assert.FileExists(t, options.qrSvg)
+	assert.Equal(t, "1234\n", stdout.String())
+}
+
+func TestCommandLineOptionsWriteOutputQrFitsTerminal(t *testing.T) {
+	password := strings.Repeat("x", 40)
+	highest, err := passgen.NewQrCodeWithLevel(password, DefaultQrMargin, passgen.QrLevelHighest)
+	require.NoError(t, err)
+	low, err := passgen.NewQrCodeWithLevel(password, DefaultQrMargin, passgen.QrLevelLow)
+	require.NoError(t, err)
+	require.Less(t, low.Width(), highest.Width())
+
+	options := &CommandLineOptions{length: 40, lowercase: true, format: FormatText, qrOutput: true, qrLevel: DefaultQrLevel, qrStyle: DefaultQrStyle, qrMargin: DefaultQrMargin, environ: []string{"COLUMNS=" + strconv.Itoa(low.Width())}}
+	var stdout, stderr bytes.Buffer
+
+	err = options.WriteOutput(&stdout, &stderr, []string{password})
+
+	require.NoError(t, err)
+	for _, line := range strings.Split(strings.TrimSpace(stdout.String()), "\n") {
+		line = strings.NewReplacer("\033[40;37;1m", "", "\033[0m", "").Replace(line)
+		if !strings.HasPrefix(line, "Password: ") {
+			assert.LessOrEqual(t, utf8.RuneCountInString(line), low.Width())
+		}
+	}
+}
+
+func TestCommandLineOptionsWriteOutputQrStyle(t *testing.T) {
+	options := &CommandLineOptions{length: 4, numbers: true, format: FormatText, qrOutput: true, qrLevel: DefaultQrLevel, qrStyle: "ascii"}
+	var stdout, stderr bytes.Buffer
+
+	err := options.WriteOutput(&stdout, &stderr, []string{"1234"})
+
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), "##")
+	assert.NotContains(t, stdout.String(), "\033")
+	assert.Contains(t, stdout.String(), "Password: 1234\n")
+}
+
+func TestCommandLineOptionsQrContent(t *testing.T) {
+	t.Run("password", func(t *testing.T) {
+		options := &CommandLineOptions{}
+
+		content, err := options.qrContent("secret12")
+
+		require.NoError(t, err)
+		assert.Equal(t, "secret12", content)
+	})
+
+	t.Run("Wi-Fi network", func(t *testing.T) {
+		parser := NewCommandLineParser()
+		options, err := parser.Parse([]string{"-q", "--wifi-ssid", "Guest;Net", "--wifi-security", "sae", "--wifi-hidden"})
+		require.NoError(t, err)
+		require.NoError(t, options.Validate())
+
+		content, err := options.qrContent("secret12")
+
+		require.NoError(t, err)
+		assert.Equal(t, `WIFI:T:SAE;S:Guest\;Net;P:secret12;H:true;;`, content)
+	})
+}
@@ -0,0 +1,24 @@
+package internal
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/amirhossein-fzl/passgen/pkg/passgen"
+)
+
+// LoadPolicy reads a JSON password policy file, see passgen.PasswordPolicy
+// for the supported rules.
+func LoadPolicy(path string) (*passgen.PasswordPolicy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	policy, err := passgen.ParsePasswordPolicy(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return policy, nil
+}
@@ -0,0 +1,107 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/amirhossein-fzl/passgen/pkg/passgen"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeTestPolicy(t *testing.T, contents string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "policy.json")
+	require.NoError(t, os.WriteFile(path, []byte(contents), 0600))
+
+	return path
+}
+
+func TestLoadPolicy(t *testing.T) {
+	t.Run("valid policy", func(t *testing.T) {
+		policy, err := LoadPolicy(writeTestPolicy(t, `{"max_length": 16, "require": ["symbols"]}`))
+
+		require.NoError(t, err)
+		assert.Equal(t, 16, policy.MaxLength)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := LoadPolicy(filepath.Join(t.TempDir(), "missing.json"))
+
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("invalid policy names the file", func(t *testing.T) {
+		path := writeTestPolicy(t, `{"min_length": 20, "max_length": 10}`)
+
+		_, err := LoadPolicy(path)
+
+		assert.ErrorIs(t, err, passgen.ErrPolicyLengthRange)
+		assert.ErrorContains(t, err, path)
+	})
+}
+
+func TestCommandLineOptionsGenerateWithPolicy(t *testing.T) {
+	path := writeTestPolicy(t, `{"max_length": 10, "allowed_symbols": "!", "forbidden_start": "!0123456789", "require": ["symbols", "numbers"]}`)
+
+	options, err := NewCommandLineParser().Parse([]string{"--policy", path, "-n", "20"})
+	require.NoError(t, err)
+	require.NoError(t, options.Validate())
+
+	passwords, err := options.Generate()
+
+	require.NoError(t, err)
+	require.Len(t, passwords, 20)
+	for _, password := range passwords {
+		assert.NoError(t, options.policy.Check(password), password)
+		assert.Len(t, password, 10)
+	}
+	assert.Equal(t, []string{"uppercase", "lowercase", "numbers", "symbols"}, options.Classes())
+}
+
+func TestCommandLineOptionsPolicyAppliedOnce(t *testing.T) {
+	path := writeTestPolicy(t, `{"forbidden": "xyz"}`)
+
+	options, err := NewCommandLineParser().Parse([]string{"--policy", path, "--exclude", "abc"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "abc", options.passwordGeneratorOptions().Exclude)
+	assert.Equal(t, "abcxyz", options.ToPasswordGeneratorOptions().Exclude)
+}
+
+func TestCommandLineOptionsValidatePolicyWithMode(t *testing.T) {
+	path := writeTestPolicy(t, `{"max_length": 10}`)
+
+	options, err := NewCommandLineParser().Parse([]string{"--policy", path, "--words", "4"})
+	require.NoError(t, err)
+
+	assert.Equal(t, ErrPolicyWithMode, options.Validate())
+}
+
+func TestCommandLineOptionsValidatePolicyLength(t *testing.T) {
+	path := writeTestPolicy(t, `{"min_length": 8, "max_length": 16}`)
+
+	t.Run("explicit length outside of the range", func(t *testing.T) {
+		options, err := NewCommandLineParser().Parse([]string{"--policy", path, "-l", "30"})
+		require.NoError(t, err)
+
+		assert.Equal(t, passgen.ErrPolicyLength, options.Validate())
+	})
+
+	t.Run("explicit length inside of the range", func(t *testing.T) {
+		options, err := NewCommandLineParser().Parse([]string{"--policy", path, "--length", "16"})
+		require.NoError(t, err)
+
+		assert.NoError(t, options.Validate())
+	})
+
+	t.Run("default length is clamped", func(t *testing.T) {
+		options, err := NewCommandLineParser().Parse([]string{"--policy", writeTestPolicy(t, `{"min_length": 20}`)})
+		require.NoError(t, err)
+
+		require.NoError(t, options.Validate())
+		assert.Equal(t, 20, options.ToPasswordGeneratorOptions().Length)
+	})
+}
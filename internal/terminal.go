@@ -0,0 +1,22 @@
+package internal
+
+import (
+	"io"
+	"os"
+	"strconv"
+)
+
+// TerminalWidth returns the number of columns of the terminal w writes to,
+// preferring the COLUMNS variable of environ over asking the terminal itself.
+// It returns 0 when the width is unknown, e.g. when w is a file or a buffer.
+func TerminalWidth(w io.Writer, environ []string) int {
+	if columns, err := strconv.Atoi(getenv(environ, "COLUMNS")); err == nil && columns > 0 {
+		return columns
+	}
+
+	if file, ok := w.(*os.File); ok {
+		return terminalWidth(file)
+	}
+
+	return 0
+}
@@ -0,0 +1,9 @@
+//go:build !linux && !darwin
+
+package internal
+
+import "os"
+
+func terminalWidth(file *os.File) int {
+	return 0
+}
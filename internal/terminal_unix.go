@@ -0,0 +1,32 @@
+//go:build linux || darwin
+
+package internal
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+type winsize struct {
+	rows    uint16
+	columns uint16
+	xpixel  uint16
+	ypixel  uint16
+}
+
+func terminalWidth(file *os.File) int {
+	var size winsize
+
+	_, _, errno := syscall.Syscall(
+		syscall.SYS_IOCTL,
+		file.Fd(),
+		uintptr(syscall.TIOCGWINSZ),
+		uintptr(unsafe.Pointer(&size)),
+	)
+	if errno != 0 {
+		return 0
+	}
+
+	return int(size.columns)
+}
@@ -0,0 +1,77 @@
+package internal
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/amirhossein-fzl/passgen/pkg/passgen"
+)
+
+const TotpCodeCommand = "totp-code"
+
+var (
+	ErrTotpSecretArgument = errors.New("TOTP secrets are read from stdin, never from the command line.")
+	ErrTotpSecretMissing  = errors.New("Expected a TOTP secret or otpauth:// URI on stdin.")
+)
+
+// RunTotpCode prints the current code for a TOTP secret read from stdin, so
+// the secret never shows up in the shell history or the process list.
+func RunTotpCode(args []string, stdin io.Reader, stdout, stderr io.Writer, now time.Time) error {
+	commandLine := newCommandLineOptions(TotpCodeCommand)
+	flagSet := newTotpCodeFlagSet(stderr, commandLine)
+
+	if err := flagSet.Parse(args); err != nil {
+		return err
+	}
+
+	if flagSet.NArg() > 0 {
+		return ErrTotpSecretArgument
+	}
+
+	input, err := bufio.NewReader(stdin).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return err
+	}
+
+	secret := strings.TrimSpace(input)
+	if secret == "" {
+		return ErrTotpSecretMissing
+	}
+
+	options := commandLine.ToTotpGeneratorOptions()
+
+	if strings.HasPrefix(secret, "otpauth://") {
+		secret, options, err = passgen.ParseTotpURIWithOptions(secret, *options)
+		if err != nil {
+			return err
+		}
+	}
+
+	code, err := passgen.TotpCode(secret, *options, now)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(stdout, code)
+	return nil
+}
+
+func newTotpCodeFlagSet(stderr io.Writer, options *CommandLineOptions) *flag.FlagSet {
+	flags := totpCodeFlags(options)
+	flagSet := newFlagSet(TotpCodeCommand, flags, stderr)
+
+	flagSet.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: %s %s [options] < secret\n\n", ProgramName, TotpCodeCommand)
+		fmt.Fprintf(stderr, "Print the current TOTP code for a Base32 secret or otpauth:// URI read from stdin.\n\n")
+		fmt.Fprintf(stderr, "Options:\n")
+		printFlags(stderr, flags)
+		fmt.Fprintf(stderr, "\nParameters contained in an otpauth:// URI take precedence over the options.\n")
+	}
+
+	return flagSet
+}
@@ -0,0 +1,77 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/base32"
+	"flag"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/amirhossein-fzl/passgen/pkg/passgen"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunTotpCode(t *testing.T) {
+	// RFC 6238 test secret, see pkg/passgen/totp_generator_test.go.
+	secret := base32.StdEncoding.EncodeToString([]byte("12345678901234567890"))
+	now := time.Unix(59, 0)
+
+	tests := []struct {
+		name     string
+		args     []string
+		stdin    string
+		expected string
+	}{
+		{name: "raw secret", stdin: secret + "\n", expected: "287082\n"},
+		{name: "raw secret with options", args: []string{"--totp-digits", "8"}, stdin: secret, expected: "94287082\n"},
+		{name: "otpauth URI", stdin: "otpauth://totp/ACME:ci?secret=" + secret + "&digits=8", expected: "94287082\n"},
+		{name: "otpauth URI with options", args: []string{"--totp-digits", "8"}, stdin: "otpauth://totp/ACME:ci?secret=" + secret, expected: "94287082\n"},
+		{name: "otpauth URI overrides options", args: []string{"--totp-digits", "7"}, stdin: "otpauth://totp/ACME:ci?secret=" + secret + "&digits=8", expected: "94287082\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+
+			err := RunTotpCode(tt.args, strings.NewReader(tt.stdin), &stdout, &stderr, now)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, stdout.String())
+		})
+	}
+
+	t.Run("empty stdin", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+
+		err := RunTotpCode(nil, strings.NewReader(""), &stdout, &stderr, now)
+
+		assert.Equal(t, ErrTotpSecretMissing, err)
+	})
+
+	t.Run("secret as argument", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+
+		err := RunTotpCode([]string{secret}, strings.NewReader(""), &stdout, &stderr, now)
+
+		assert.Equal(t, ErrTotpSecretArgument, err)
+	})
+
+	t.Run("invalid secret", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+
+		err := RunTotpCode(nil, strings.NewReader("not a secret!"), &stdout, &stderr, now)
+
+		assert.Equal(t, passgen.ErrInvalidTotpSecret, err)
+	})
+
+	t.Run("help", func(t *testing.T) {
+		var stdout, stderr bytes.Buffer
+
+		err := RunTotpCode([]string{"-h"}, strings.NewReader(""), &stdout, &stderr, now)
+
+		assert.Equal(t, flag.ErrHelp, err)
+		assert.Contains(t, stderr.String(), "Usage: passgen totp-code")
+	})
+}
@@ -0,0 +1,169 @@
+package passgen
+
+import (
+	"slices"
+	"strings"
+)
+
+const (
+	UppercaseChars = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	LowercaseChars = "abcdefghijklmnopqrstuvwxyz"
+	NumberChars    = "0123456789"
+	SymbolChars    = "`~!@#$%^&*()_-+=\\|[]'\";:/?<>.,"
+	AmbiguousChars = "l1I|O0"
+)
+
+type CharsetBuilder struct {
+	characters []rune
+	seen       map[rune]struct{}
+	duplicates []rune
+	overlaps   []rune
+}
+
+func NewCharsetBuilder() *CharsetBuilder {
+	return &CharsetBuilder{}
+}
+
+func NewCharsetBuilderFromPasswordGeneratorOptions(options PasswordGeneratorOptions) *CharsetBuilder {
+	charset_builder := NewCharsetBuilder()
+
+	if options.Uppercase {
+		charset_builder.WithUppercase()
+	}
+
+	if options.Lowercase {
+		charset_builder.WithLowercase()
+	}
+
+	if options.Numbers {
+		charset_builder.WithNumbers()
+	}
+
+	if options.Symbols {
+		charset_builder.WithCustom(options.symbolCharacters())
+	}
+
+	if custom_character := options.Custom; custom_character != "" {
+		charset_builder.WithCustom(custom_character)
+	}
+
+	charset_builder.Without(options.excludedCharacters())
+
+	return charset_builder
+}
+
+func (c *CharsetBuilder) Characters() string {
+	return string(c.characters)
+}
+
+func (c *CharsetBuilder) WithUppercase() *CharsetBuilder {
+	c.add(UppercaseChars)
+	return c
+}
+
+func (c *CharsetBuilder) WithLowercase() *CharsetBuilder {
+	c.add(LowercaseChars)
+	return c
+}
+
+func (c *CharsetBuilder) WithNumbers() *CharsetBuilder {
+	c.add(NumberChars)
+	return c
+}
+
+func (c *CharsetBuilder) WithSymbols() *CharsetBuilder {
+	c.add(SymbolChars)
+	return c
+}
+
+func (c *CharsetBuilder) WithCustom(characters string) *CharsetBuilder {
+	if characters != "" {
+		c.add(characters)
+	}
+	return c
+}
+
+// Without removes the given characters from the pool built so far.
+func (c *CharsetBuilder) Without(characters string) *CharsetBuilder {
+	if characters == "" {
+		return c
+	}
+
+	excluded := func(character rune) bool {
+		return strings.ContainsRune(characters, character)
+	}
+
+	c.characters = slices.DeleteFunc(c.characters, excluded)
+	c.duplicates = slices.DeleteFunc(c.duplicates, excluded)
+	c.overlaps = slices.DeleteFunc(c.overlaps, excluded)
+	for character := range c.seen {
+		if excluded(character) {
+			delete(c.seen, character)
+		}
+	}
+
+	return c
+}
+
+func (c *CharsetBuilder) Reset() *CharsetBuilder {
+	c.characters = nil
+	c.seen = nil
+	c.duplicates = nil
+	c.overlaps = nil
+	return c
+}
+
+// Duplicates returns the characters that were added more than once, in the
+// order they were first repeated. They are kept only once in the pool.
+func (c *CharsetBuilder) Duplicates() string {
+	return string(c.duplicates)
+}
+
+// Overlaps returns the duplicates that are in more than one of the added
+// sets, e.g. "abc" after WithLowercase, as opposed to a character repeated
+// within a single set.
+func (c *CharsetBuilder) Overlaps() string {
+	return string(c.overlaps)
+}
+
+// Repeats returns the duplicates that are only repeated within a single set,
+// e.g. "a" for WithCustom("aab").
+func (c *CharsetBuilder) Repeats() string {
+	return string(slices.DeleteFunc(slices.Clone(c.duplicates), func(character rune) bool {
+		return slices.Contains(c.overlaps, character)
+	}))
+}
+
+func (c *CharsetBuilder) add(characters string) {
+	if c.seen == nil {
+		c.seen = make(map[rune]struct{})
+	}
+
+	added := make(map[rune]struct{})
+	for _, character := range characters {
+		_, repeated := added[character]
+		added[character] = struct{}{}
+
+		if _, ok := c.seen[character]; !ok {
+			c.seen[character] = struct{}{}
+			c.characters = append(c.characters, character)
+			continue
+		}
+
+		if !slices.Contains(c.duplicates, character) {
+			c.duplicates = append(c.duplicates, character)
+		}
+
+		if !repeated && !slices.Contains(c.overlaps, character) {
+			c.overlaps = append(c.overlaps, character)
+		}
+	}
+}
+
+func (c *CharsetBuilder) Length() int {
+	return len(c.characters)
+}
+
+func (c *CharsetBuilder) IsEmpty() bool {
+	return len(c.characters) == 0
+}
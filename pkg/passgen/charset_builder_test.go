@@ -1,12 +1,16 @@
-package internal
+package passgen
 
 import (
 	"testing"
+	"unicode/utf8"
 
 	"github.com/stretchr/testify/assert"
 )
 
-const customChars = "لورم ایپسوم"
+const (
+	customChars       = "لورم ایپسوم"
+	uniqueCustomChars = "لورم ایپس"
+)
 
 func TestNewCharsetBuilder(t *testing.T) {
 	builder := NewCharsetBuilder()
@@ -73,8 +77,9 @@ func TestCharsetBuilderWithCustom(t *testing.T) {
 		assert.Same(t, builder, result)
 
 		chars := builder.Characters()
-		assert.Equal(t, customChars, chars)
-		assert.Equal(t, len(customChars), builder.Length())
+		assert.Equal(t, uniqueCustomChars, chars)
+		assert.Equal(t, utf8.RuneCountInString(uniqueCustomChars), builder.Length())
+		assert.Equal(t, "وم", builder.Duplicates())
 		assert.Subset(t, []byte(chars), []byte(customChars))
 	})
 
@@ -100,7 +105,7 @@ func TestCharsetBuilderChainedCalls(t *testing.T) {
 	assert.Same(t, builder, result)
 
 	chars := builder.Characters()
-	expectedLength := len(UppercaseChars) + len(LowercaseChars) + len(NumberChars) + len(SymbolChars) + len(customChars)
+	expectedLength := len(UppercaseChars) + len(LowercaseChars) + len(NumberChars) + len(SymbolChars) + len(uniqueCustomChars)
 	assert.Len(t, chars, expectedLength)
 	chars_byte := []byte(chars)
 
@@ -111,6 +116,68 @@ func TestCharsetBuilderChainedCalls(t *testing.T) {
 	assert.Subset(t, chars_byte, []byte(customChars))
 }
 
+func TestCharsetBuilderDeduplicatesOverlappingSets(t *testing.T) {
+	builder := NewCharsetBuilder()
+	builder.WithLowercase().WithCustom("abc").WithCustom("xyz1")
+
+	assert.Equal(t, LowercaseChars+"1", builder.Characters())
+	assert.Equal(t, len(LowercaseChars)+1, builder.Length())
+	assert.Equal(t, "abcxyz", builder.Duplicates())
+	assert.Equal(t, "abcxyz", builder.Overlaps())
+	assert.Empty(t, builder.Repeats())
+}
+
+func TestCharsetBuilderSeparatesRepeatsFromOverlaps(t *testing.T) {
+	builder := NewCharsetBuilder()
+	builder.WithCustom("aab").WithCustom("bcc")
+
+	assert.Equal(t, "abc", builder.Characters())
+	assert.Equal(t, "abc", builder.Duplicates())
+	assert.Equal(t, "b", builder.Overlaps())
+	assert.Equal(t, "ac", builder.Repeats())
+}
+
+func TestCharsetBuilderPreservesInsertionOrder(t *testing.T) {
+	builder := NewCharsetBuilder()
+	builder.WithCustom("cabbac").WithNumbers()
+
+	assert.Equal(t, "cab"+NumberChars, builder.Characters())
+	assert.Equal(t, "bac", builder.Duplicates())
+}
+
+func TestCharsetBuilderWithout(t *testing.T) {
+	t.Run("removes characters from pool", func(t *testing.T) {
+		builder := NewCharsetBuilder()
+		result := builder.WithNumbers().WithUppercase().Without(AmbiguousChars)
+
+		assert.Same(t, builder, result)
+		assert.Equal(t, "23456789ABCDEFGHJKLMNPQRSTUVWXYZ", builder.Characters())
+		assert.Equal(t, len(NumberChars)+len(UppercaseChars)-len("01IO"), builder.Length())
+	})
+
+	t.Run("removes excluded duplicates", func(t *testing.T) {
+		builder := NewCharsetBuilder()
+		builder.WithNumbers().WithCustom("01").Without("1")
+
+		assert.Equal(t, "0", builder.Duplicates())
+	})
+
+	t.Run("allows adding removed characters again", func(t *testing.T) {
+		builder := NewCharsetBuilder()
+		builder.WithCustom("abc").Without("b").WithCustom("b")
+
+		assert.Equal(t, "acb", builder.Characters())
+		assert.Empty(t, builder.Duplicates())
+	})
+
+	t.Run("can empty the pool", func(t *testing.T) {
+		builder := NewCharsetBuilder()
+		builder.WithNumbers().Without(NumberChars)
+
+		assert.True(t, builder.IsEmpty())
+	})
+}
+
 func TestCharsetBuilderReset(t *testing.T) {
 	builder := NewCharsetBuilder()
 	builder.WithUppercase().WithLowercase().WithNumbers()
@@ -121,6 +188,7 @@ func TestCharsetBuilderReset(t *testing.T) {
 	result := builder.Reset()
 	assert.Same(t, builder, result)
 	assert.Empty(t, builder.Characters())
+	assert.Empty(t, builder.Duplicates())
 	assert.True(t, builder.IsEmpty())
 	assert.Equal(t, 0, builder.Length())
 }
@@ -151,6 +219,7 @@ func TestCharsetBuilderEdgeCases(t *testing.T) {
 
 		chars := builder.Characters()
 		assert.Len(t, chars, len(unicodeChars))
+		assert.Equal(t, utf8.RuneCountInString(unicodeChars), builder.Length())
 		for _, char := range unicodeChars {
 			assert.Contains(t, chars, string(char))
 		}
@@ -194,7 +263,7 @@ func TestNewCharsetBuilderFromPasswordGeneratorOptions(t *testing.T) {
 
 	charset := NewCharsetBuilderFromPasswordGeneratorOptions(options)
 	chars := charset.Characters()
-	expectedLength := len(UppercaseChars) + len(LowercaseChars) + len(NumberChars) + len(SymbolChars) + len(customChars)
+	expectedLength := len(UppercaseChars) + len(LowercaseChars) + len(NumberChars) + len(SymbolChars) + len(uniqueCustomChars)
 	assert.Len(t, chars, expectedLength)
 	chars_byte := []byte(chars)
 
@@ -205,6 +274,24 @@ func TestNewCharsetBuilderFromPasswordGeneratorOptions(t *testing.T) {
 	assert.Subset(t, chars_byte, []byte(customChars))
 }
 
+func TestNewCharsetBuilderFromPasswordGeneratorOptionsWithExclusions(t *testing.T) {
+	options := PasswordGeneratorOptions{
+		Lowercase:        true,
+		Uppercase:        true,
+		Numbers:          true,
+		Exclude:          "xyz",
+		ExcludeAmbiguous: true,
+	}
+
+	charset := NewCharsetBuilderFromPasswordGeneratorOptions(options)
+
+	assert.Equal(t, len(UppercaseChars)+len(LowercaseChars)+len(NumberChars)-len("xyz")-len("l1IO0"), charset.Length())
+	assert.NotContains(t, charset.Characters(), "x")
+	for _, character := range AmbiguousChars {
+		assert.NotContains(t, charset.Characters(), string(character))
+	}
+}
+
 func BenchmarkCharsetBuilderSingleType(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		builder := NewCharsetBuilder()
@@ -0,0 +1,171 @@
+package passgen
+
+import (
+	"fmt"
+	"math"
+)
+
+// GuessesPerSecond is the attacker speed assumed by crack time estimates,
+// roughly an offline attack against a fast hash on a GPU cluster.
+const GuessesPerSecond = 1e10
+
+type Entropy struct {
+	Bits         float64
+	CrackSeconds float64
+}
+
+func NewEntropy(bits float64) Entropy {
+	return Entropy{
+		Bits:         bits,
+		CrackSeconds: math.Exp2(bits-1) / GuessesPerSecond,
+	}
+}
+
+// CalculatePasswordEntropy estimates the entropy of passwords generated with
+// the given options. Positions reserved for minimum character counts only draw
+// from their class, and with avoid repeats enabled each position can only draw
+// from its pool minus the recently used characters.
+func CalculatePasswordEntropy(options PasswordGeneratorOptions) (Entropy, error) {
+	charset := NewCharsetBuilderFromPasswordGeneratorOptions(options)
+	if charset.IsEmpty() {
+		return Entropy{}, ErrEmptyCharset
+	}
+
+	if err := options.validateMinimums(); err != nil {
+		return Entropy{}, err
+	}
+
+	// Reserve the first positions like the generator reserves random ones, the
+	// estimate does not depend on where they are.
+	positions := make([]int, max(options.Length, 0))
+	for i := range positions {
+		positions[i] = i
+	}
+
+	positionCharsets, err := layoutPositionCharsets(options, charset.characters, positions)
+	if err != nil {
+		return Entropy{}, err
+	}
+
+	bits := 0.0
+	for i, characters := range positionCharsets {
+		avoidRepeats := normalizeAvoidRepeats(options.AvoidRepeats, len(characters))
+		bits += math.Log2(float64(len(characters) - min(i, avoidRepeats)))
+	}
+
+	return NewEntropy(bits), nil
+}
+
+func CalculatePassphraseEntropy(options PassphraseGeneratorOptions) Entropy {
+	words := max(options.Words, 0)
+	bits := float64(words) * math.Log2(float64(len(effLargeWordlist())))
+
+	if words > 0 && options.Number {
+		bits += math.Log2(float64(len(NumberChars))) + math.Log2(float64(words))
+	}
+
+	if words > 0 && options.Symbol {
+		bits += math.Log2(float64(len(SymbolChars))) + math.Log2(float64(words))
+	}
+
+	return NewEntropy(bits)
+}
+
+func CalculateTotpEntropy(options TotpGeneratorOptions) Entropy {
+	return NewEntropy(float64(max(options.Bytes, 0) * 8))
+}
+
+// CalculatePronounceableEntropy reports the real entropy of pronounceable
+// passwords, which is much lower than a random password of the same length.
+func CalculatePronounceableEntropy(options PronounceableGeneratorOptions) Entropy {
+	digits := max(options.Digits, 0)
+	letters := max(options.Length-digits, 0)
+	if letters == 0 && digits == 0 {
+		return NewEntropy(0)
+	}
+
+	consonantBits := math.Log2(float64(len(PronounceableConsonants)))
+	vowelBits := math.Log2(float64(len(PronounceableVowels)))
+	odd, even := float64((letters+1)/2), float64(letters/2)
+
+	bits := 0.0
+	if letters > 0 {
+		startWithConsonant := odd*consonantBits + even*vowelBits
+		startWithVowel := odd*vowelBits + even*consonantBits
+		bits = log2Sum(startWithConsonant, startWithVowel)
+	}
+
+	if options.Capitalize && letters > 0 {
+		bits += math.Log2(float64(letters))
+	}
+
+	bits += float64(digits)*math.Log2(float64(len(NumberChars))) + log2Binomial(letters+digits, digits)
+
+	return NewEntropy(bits)
+}
+
+func log2Sum(a, b float64) float64 {
+	high, low := max(a, b), min(a, b)
+	return high + math.Log2(1+math.Exp2(low-high))
+}
+
+func log2Binomial(n, k int) float64 {
+	nFactorial, _ := math.Lgamma(float64(n + 1))
+	kFactorial, _ := math.Lgamma(float64(k + 1))
+	nkFactorial, _ := math.Lgamma(float64(n - k + 1))
+
+	return (nFactorial - kFactorial - nkFactorial) / math.Ln2
+}
+
+func (e Entropy) CrackTime() string {
+	const (
+		minute  = 60
+		hour    = 60 * minute
+		day     = 24 * hour
+		month   = 30 * day
+		year    = 365 * day
+		century = 100 * year
+	)
+
+	units := []struct {
+		name    string
+		seconds float64
+	}{
+		{"century", century},
+		{"year", year},
+		{"month", month},
+		{"day", day},
+		{"hour", hour},
+		{"minute", minute},
+		{"second", 1},
+	}
+
+	if e.CrackSeconds < 1 {
+		return "less than a second"
+	}
+
+	if e.CrackSeconds >= 100*century {
+		return "centuries"
+	}
+
+	for _, unit := range units {
+		if e.CrackSeconds >= unit.seconds {
+			count := math.Round(e.CrackSeconds / unit.seconds)
+			if count == 1 {
+				return fmt.Sprintf("1 %s", unit.name)
+			}
+
+			if unit.name == "century" {
+				return fmt.Sprintf("%.0f centuries", count)
+			}
+
+			return fmt.Sprintf("%.0f %ss", count, unit.name)
+		}
+	}
+
+	return "less than a second"
+}
+
+func (e Entropy) String() string {
+	return fmt.Sprintf("%.2f bits (estimated crack time: %s)", e.Bits, e.CrackTime())
+}
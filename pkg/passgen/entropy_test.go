@@ -0,0 +1,155 @@
+package passgen
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalculatePasswordEntropy(t *testing.T) {
+	t.Run("should multiply pool bits by length without avoid repeats", func(t *testing.T) {
+		options := PasswordGeneratorOptions{Length: 10, Lowercase: true}
+
+		entropy, err := CalculatePasswordEntropy(options)
+
+		require.NoError(t, err)
+		assert.InDelta(t, 10*math.Log2(26), entropy.Bits, 1e-9)
+	})
+
+	t.Run("should reduce pool per position with avoid repeats", func(t *testing.T) {
+		options := PasswordGeneratorOptions{Length: 4, Numbers: true, AvoidRepeats: 2}
+
+		entropy, err := CalculatePasswordEntropy(options)
+
+		require.NoError(t, err)
+		expected := math.Log2(10) + math.Log2(9) + math.Log2(8) + math.Log2(8)
+		assert.InDelta(t, expected, entropy.Bits, 1e-9)
+	})
+
+	t.Run("should use deduplicated pool size", func(t *testing.T) {
+		options := PasswordGeneratorOptions{Length: 1, Lowercase: true, Custom: "abc"}
+
+		entropy, err := CalculatePasswordEntropy(options)
+
+		require.NoError(t, err)
+		assert.InDelta(t, math.Log2(26), entropy.Bits, 1e-9)
+	})
+
+	t.Run("should draw reserved positions from their class", func(t *testing.T) {
+		options := PasswordGeneratorOptions{Length: 4, Lowercase: true, Numbers: true, MinNumbers: 4, AvoidRepeats: 1}
+
+		entropy, err := CalculatePasswordEntropy(options)
+
+		require.NoError(t, err)
+		assert.InDelta(t, math.Log2(10*9*9*9), entropy.Bits, 1e-9)
+	})
+
+	t.Run("should mix reserved and free positions", func(t *testing.T) {
+		options := PasswordGeneratorOptions{Length: 4, Lowercase: true, Numbers: true, MinNumbers: 1, Exclude: "0"}
+
+		entropy, err := CalculatePasswordEntropy(options)
+
+		require.NoError(t, err)
+		assert.InDelta(t, math.Log2(9)+3*math.Log2(35), entropy.Bits, 1e-9)
+	})
+
+	t.Run("should return error for minimums exceeding length", func(t *testing.T) {
+		_, err := CalculatePasswordEntropy(PasswordGeneratorOptions{Length: 2, Numbers: true, MinNumbers: 3})
+
+		assert.Equal(t, ErrMinimumsExceedLength, err)
+	})
+
+	t.Run("should return error for empty charset", func(t *testing.T) {
+		_, err := CalculatePasswordEntropy(PasswordGeneratorOptions{Length: 8})
+
+		assert.Equal(t, ErrEmptyCharset, err)
+	})
+}
+
+func TestCalculatePassphraseEntropy(t *testing.T) {
+	t.Run("should count wordlist bits per word", func(t *testing.T) {
+		entropy := CalculatePassphraseEntropy(PassphraseGeneratorOptions{Words: 6})
+
+		assert.InDelta(t, 6*math.Log2(7776), entropy.Bits, 1e-9)
+	})
+
+	t.Run("should add inserted number and symbol", func(t *testing.T) {
+		entropy := CalculatePassphraseEntropy(PassphraseGeneratorOptions{Words: 4, Number: true, Symbol: true})
+
+		expected := 4*math.Log2(7776) + math.Log2(10) + math.Log2(4) + math.Log2(float64(len(SymbolChars))) + math.Log2(4)
+		assert.InDelta(t, expected, entropy.Bits, 1e-9)
+	})
+
+	t.Run("should handle zero words", func(t *testing.T) {
+		entropy := CalculatePassphraseEntropy(PassphraseGeneratorOptions{Words: 0, Number: true})
+
+		assert.Zero(t, entropy.Bits)
+	})
+}
+
+func TestCalculatePronounceableEntropy(t *testing.T) {
+	consonants, vowels := float64(len(PronounceableConsonants)), float64(len(PronounceableVowels))
+
+	t.Run("should count both starting letters", func(t *testing.T) {
+		entropy := CalculatePronounceableEntropy(PronounceableGeneratorOptions{Length: 4})
+
+		assert.InDelta(t, math.Log2(2*consonants*consonants*vowels*vowels), entropy.Bits, 1e-9)
+	})
+
+	t.Run("should handle odd length", func(t *testing.T) {
+		entropy := CalculatePronounceableEntropy(PronounceableGeneratorOptions{Length: 3})
+
+		expected := math.Log2(consonants*consonants*vowels + vowels*vowels*consonants)
+		assert.InDelta(t, expected, entropy.Bits, 1e-9)
+	})
+
+	t.Run("should add capital and digit positions", func(t *testing.T) {
+		entropy := CalculatePronounceableEntropy(PronounceableGeneratorOptions{Length: 4, Capitalize: true, Digits: 2})
+
+		expected := math.Log2(2*consonants*vowels) + math.Log2(2) + 2*math.Log2(10) + math.Log2(6)
+		assert.InDelta(t, expected, entropy.Bits, 1e-9)
+	})
+
+	t.Run("should be lower than a random password of the same length", func(t *testing.T) {
+		pronounceable := CalculatePronounceableEntropy(PronounceableGeneratorOptions{Length: 12})
+		random, err := CalculatePasswordEntropy(PasswordGeneratorOptions{Length: 12, Lowercase: true})
+
+		require.NoError(t, err)
+		assert.Less(t, pronounceable.Bits, random.Bits)
+	})
+}
+
+func TestEntropyCrackTime(t *testing.T) {
+	tests := []struct {
+		name     string
+		seconds  float64
+		expected string
+	}{
+		{name: "instant", seconds: 0.5, expected: "less than a second"},
+		{name: "one second", seconds: 1, expected: "1 second"},
+		{name: "seconds", seconds: 42, expected: "42 seconds"},
+		{name: "minutes", seconds: 180, expected: "3 minutes"},
+		{name: "hours", seconds: 5 * 3600, expected: "5 hours"},
+		{name: "days", seconds: 3 * 86400, expected: "3 days"},
+		{name: "years", seconds: 20 * 365 * 86400, expected: "20 years"},
+		{name: "few centuries", seconds: 300 * 365 * 86400, expected: "3 centuries"},
+		{name: "too long", seconds: 1e20, expected: "centuries"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entropy := Entropy{CrackSeconds: tt.seconds}
+			assert.Equal(t, tt.expected, entropy.CrackTime())
+		})
+	}
+}
+
+func TestNewEntropy(t *testing.T) {
+	entropy := NewEntropy(41)
+
+	assert.Equal(t, 41.0, entropy.Bits)
+	assert.InDelta(t, math.Exp2(40)/GuessesPerSecond, entropy.CrackSeconds, 1e-9)
+	assert.Contains(t, entropy.String(), "41.00 bits")
+}
@@ -0,0 +1,6 @@
+// Package passgen generates cryptographically secure passwords and renders
+// them as QR codes. It is the library behind the passgen command line tool.
+//
+// The exported API follows semantic versioning through the release tags of
+// the module: breaking changes only happen together with a new major version.
+package passgen
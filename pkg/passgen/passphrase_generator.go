@@ -0,0 +1,101 @@
+package passgen
+
+import (
+	_ "embed"
+	"math"
+	"strings"
+	"sync"
+)
+
+// The EFF large wordlist for dice-generated passphrases:
+// https://www.eff.org/deeplinks/2016/07/new-wordlists-random-passphrases
+//
+//go:embed eff_large_wordlist.txt
+var effLargeWordlistFile string
+
+var effLargeWordlist = sync.OnceValue(func() []string {
+	lines := strings.Split(strings.TrimSpace(effLargeWordlistFile), "\n")
+	words := make([]string, 0, len(lines))
+
+	for _, line := range lines {
+		fields := strings.Fields(line)
+		words = append(words, fields[len(fields)-1])
+	}
+
+	return words
+})
+
+func GeneratePassphrase(options PassphraseGeneratorOptions) (string, error) {
+	random := randomSourceOrDefault(options.Random)
+	wordlist := effLargeWordlist()
+	words := make([]string, max(options.Words, 0))
+
+	for i := range words {
+		index, err := randomInt(random, 0, len(wordlist)-1)
+		if err != nil {
+			return "", err
+		}
+
+		words[i] = wordlist[index]
+		if options.Capitalize {
+			words[i] = strings.ToUpper(words[i][:1]) + words[i][1:]
+		}
+	}
+
+	if len(words) == 0 {
+		return "", nil
+	}
+
+	if options.Number {
+		if err := appendToRandomWord(random, words, NumberChars); err != nil {
+			return "", err
+		}
+	}
+
+	if options.Symbol {
+		if err := appendToRandomWord(random, words, SymbolChars); err != nil {
+			return "", err
+		}
+	}
+
+	return strings.Join(words, options.Separator), nil
+}
+
+// PassphraseByteLengthRange returns the length in bytes of the shortest and the
+// longest passphrase the options can generate.
+func PassphraseByteLengthRange(options PassphraseGeneratorOptions) (int, int) {
+	if options.Words <= 0 {
+		return 0, 0
+	}
+
+	shortest, longest := math.MaxInt, 0
+	for _, word := range effLargeWordlist() {
+		shortest, longest = min(shortest, len(word)), max(longest, len(word))
+	}
+
+	// The appended number and symbol are single ASCII characters.
+	extra := len(options.Separator) * (options.Words - 1)
+	if options.Number {
+		extra++
+	}
+	if options.Symbol {
+		extra++
+	}
+
+	return options.Words*shortest + extra, options.Words*longest + extra
+}
+
+func appendToRandomWord(random RandomSource, words []string, charset string) error {
+	index, err := randomInt(random, 0, len(words)-1)
+	if err != nil {
+		return err
+	}
+
+	character, err := pickRandomChar(random, []rune(charset))
+	if err != nil {
+		return err
+	}
+
+	words[index] += string(character)
+	return nil
+}
@@ -0,0 +1,35 @@
+package passgen
+
+import "errors"
+
+var (
+	ErrWordsMustBeGreaterThanZero = errors.New("Number of words must be greater than 0.")
+)
+
+type PassphraseGeneratorOptions struct {
+	Words      int    `json:"words"`
+	Separator  string `json:"separator"`
+	Capitalize bool   `json:"capitalize"`
+	Number     bool   `json:"number"`
+	Symbol     bool   `json:"symbol"`
+
+	Random RandomSource `json:"-"`
+}
+
+func NewPassphraseGeneratorOptions() *PassphraseGeneratorOptions {
+	return &PassphraseGeneratorOptions{
+		Words:      6,
+		Separator:  "-",
+		Capitalize: false,
+		Number:     false,
+		Symbol:     false,
+	}
+}
+
+func (p *PassphraseGeneratorOptions) Validate() (bool, error) {
+	if p.Words <= 0 {
+		return false, ErrWordsMustBeGreaterThanZero
+	}
+
+	return true, nil
+}
@@ -0,0 +1,41 @@
+package passgen
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPassphraseGeneratorOptions(t *testing.T) {
+	options := NewPassphraseGeneratorOptions()
+
+	assert.Equal(t, 6, options.Words)
+	assert.Equal(t, "-", options.Separator)
+	assert.False(t, options.Capitalize)
+	assert.False(t, options.Number)
+	assert.False(t, options.Symbol)
+}
+
+func TestPassphraseGeneratorOptionsValidate(t *testing.T) {
+	tests := []struct {
+		name           string
+		words          int
+		expectedResult bool
+		ErrWant        error
+	}{
+		{name: "valid words", words: 4, expectedResult: true, ErrWant: nil},
+		{name: "zero words", words: 0, expectedResult: false, ErrWant: ErrWordsMustBeGreaterThanZero},
+		{name: "negative words", words: -2, expectedResult: false, ErrWant: ErrWordsMustBeGreaterThanZero},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := NewPassphraseGeneratorOptions()
+			options.Words = tt.words
+			result, err := options.Validate()
+
+			assert.Equal(t, tt.expectedResult, result)
+			assert.Equal(t, tt.ErrWant, err)
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package passgen
+
+import (
+	"strings"
+	"testing"
+	"unicode"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEffLargeWordlist(t *testing.T) {
+	words := effLargeWordlist()
+
+	assert.Len(t, words, 7776)
+	assert.Equal(t, "abacus", words[0])
+	assert.Equal(t, "zoom", words[len(words)-1])
+}
+
+func TestGeneratePassphrase(t *testing.T) {
+	t.Run("should generate requested number of words", func(t *testing.T) {
+		options := PassphraseGeneratorOptions{Words: 5, Separator: " "}
+
+		passphrase, err := GeneratePassphrase(options)
+
+		require.NoError(t, err)
+		words := strings.Split(passphrase, " ")
+		assert.Len(t, words, 5)
+		for _, word := range words {
+			assert.Contains(t, effLargeWordlist(), word)
+		}
+	})
+
+	t.Run("should join words with separator", func(t *testing.T) {
+		options := PassphraseGeneratorOptions{Words: 4, Separator: "."}
+
+		passphrase, err := GeneratePassphrase(options)
+
+		require.NoError(t, err)
+		assert.Len(t, strings.Split(passphrase, "."), 4)
+	})
+
+	t.Run("should capitalize words", func(t *testing.T) {
+		options := PassphraseGeneratorOptions{Words: 4, Separator: " ", Capitalize: true}
+
+		passphrase, err := GeneratePassphrase(options)
+
+		require.NoError(t, err)
+		for _, word := range strings.Split(passphrase, " ") {
+			assert.True(t, unicode.IsUpper(rune(word[0])), word)
+		}
+	})
+
+	t.Run("should insert number and symbol", func(t *testing.T) {
+		options := PassphraseGeneratorOptions{Words: 3, Separator: " ", Number: true, Symbol: true}
+
+		passphrase, err := GeneratePassphrase(options)
+
+		require.NoError(t, err)
+		assert.True(t, strings.ContainsAny(passphrase, NumberChars))
+		assert.True(t, strings.ContainsAny(passphrase, SymbolChars))
+	})
+
+	t.Run("should handle zero words", func(t *testing.T) {
+		options := PassphraseGeneratorOptions{Words: 0, Number: true}
+
+		passphrase, err := GeneratePassphrase(options)
+
+		require.NoError(t, err)
+		assert.Empty(t, passphrase)
+	})
+}
+
+func TestAppendToRandomWord(t *testing.T) {
+	words := []string{"one", "two", "three"}
+
+	err := appendToRandomWord(CryptoRandom, words, "7")
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, strings.Count(strings.Join(words, " "), "7"))
+	for i, word := range []string{"one", "two", "three"} {
+		assert.Contains(t, []string{word, word + "7"}, words[i])
+	}
+}
+
+func TestPassphraseByteLengthRange(t *testing.T) {
+	t.Run("words and separators", func(t *testing.T) {
+		shortest, longest := PassphraseByteLengthRange(PassphraseGeneratorOptions{Words: 4, Separator: "--"})
+
+		assert.Equal(t, 4*3+3*2, shortest)
+		assert.Equal(t, 4*9+3*2, longest)
+	})
+
+	t.Run("number and symbol", func(t *testing.T) {
+		shortest, longest := PassphraseByteLengthRange(PassphraseGeneratorOptions{Words: 1, Number: true, Symbol: true})
+
+		assert.Equal(t, 5, shortest)
+		assert.Equal(t, 11, longest)
+	})
+
+	t.Run("no words", func(t *testing.T) {
+		shortest, longest := PassphraseByteLengthRange(PassphraseGeneratorOptions{Separator: "-"})
+
+		assert.Zero(t, shortest)
+		assert.Zero(t, longest)
+	})
+}
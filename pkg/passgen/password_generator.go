@@ -0,0 +1,309 @@
+package passgen
+
+import (
+	"crypto/rand"
+	"errors"
+	"math"
+	"math/big"
+	"slices"
+	"unicode/utf8"
+)
+
+var (
+	ErrEmptyCharset                 = errors.New("Character set is empty.")
+	ErrCannotSelectFromEmptyCharset = errors.New("Cannot select from empty charset.")
+	ErrMinCannotBeGreaterThanMax    = errors.New("min cannot be greater than max.")
+	ErrCountMustBeGreaterThanZero   = errors.New("Count must be greater than 0.")
+	ErrNotEnoughUniquePasswords     = errors.New("Options do not allow enough unique passwords.")
+)
+
+const (
+	maxUniqueAttemptsPerPassword = 100
+	maxPolicyAttemptsPerPassword = 1000
+)
+
+func GeneratePassword(options PasswordGeneratorOptions) (string, error) {
+	passwords, err := GeneratePasswords(options, 1)
+	if err != nil {
+		return "", err
+	}
+
+	return passwords[0], nil
+}
+
+func GeneratePasswords(options PasswordGeneratorOptions, n int) ([]string, error) {
+	return generatePasswords(options, nil, n, false)
+}
+
+// GenerateUniquePasswords works like GeneratePasswords but guarantees that no
+// password appears twice in the batch.
+func GenerateUniquePasswords(options PasswordGeneratorOptions, n int) ([]string, error) {
+	return generatePasswords(options, nil, n, true)
+}
+
+// GeneratePolicyPasswords applies the policy to the options and generates
+// passwords until every one of them satisfies the policy.
+func GeneratePolicyPasswords(options PasswordGeneratorOptions, policy *PasswordPolicy, n int, unique bool) ([]string, error) {
+	if _, err := policy.Validate(); err != nil {
+		return nil, err
+	}
+
+	policy.Apply(&options)
+	return generatePasswords(options, policy, n, unique)
+}
+
+func generatePasswords(options PasswordGeneratorOptions, policy *PasswordPolicy, n int, unique bool) ([]string, error) {
+	if n <= 0 {
+		return nil, ErrCountMustBeGreaterThanZero
+	}
+
+	charset := NewCharsetBuilderFromPasswordGeneratorOptions(options)
+	if charset.IsEmpty() {
+		return nil, ErrEmptyCharset
+	}
+
+	if err := options.validateMinimums(); err != nil {
+		return nil, err
+	}
+
+	if unique {
+		entropy, err := CalculatePasswordEntropy(options)
+		if err != nil {
+			return nil, err
+		}
+
+		if entropy.Bits < math.Log2(float64(n)) {
+			return nil, ErrNotEnoughUniquePasswords
+		}
+	}
+
+	return GenerateBatch(n, unique, func() (string, error) {
+		return generatePolicyPassword(options, policy, charset)
+	})
+}
+
+// GenerateBatch calls generate until it has n results. With unique, repeated
+// results are dropped and ErrNotEnoughUniquePasswords is returned once too
+// many attempts were needed.
+func GenerateBatch(n int, unique bool, generate func() (string, error)) ([]string, error) {
+	if n <= 0 {
+		return nil, ErrCountMustBeGreaterThanZero
+	}
+
+	passwords := make([]string, 0, n)
+	seen := make(map[string]struct{})
+	attempts := 0
+
+	for len(passwords) < n {
+		if attempts >= n*maxUniqueAttemptsPerPassword {
+			return nil, ErrNotEnoughUniquePasswords
+		}
+		attempts++
+
+		password, err := generate()
+		if err != nil {
+			return nil, err
+		}
+
+		if unique {
+			if _, ok := seen[password]; ok {
+				continue
+			}
+			seen[password] = struct{}{}
+		}
+
+		passwords = append(passwords, password)
+	}
+
+	return passwords, nil
+}
+
+// PasswordByteLengthRange returns the length in bytes of the shortest and the
+// longest password the options can generate.
+func PasswordByteLengthRange(options PasswordGeneratorOptions) (int, int) {
+	characters := NewCharsetBuilderFromPasswordGeneratorOptions(options).Characters()
+	if characters == "" || options.Length <= 0 {
+		return 0, 0
+	}
+
+	shortest, longest := utf8.UTFMax, 0
+	for _, character := range characters {
+		size := utf8.RuneLen(character)
+		shortest, longest = min(shortest, size), max(longest, size)
+	}
+
+	return options.Length * shortest, options.Length * longest
+}
+
+func generatePolicyPassword(options PasswordGeneratorOptions, policy *PasswordPolicy, charset *CharsetBuilder) (string, error) {
+	for range maxPolicyAttemptsPerPassword {
+		password, err := generatePasswordFromCharset(options, charset)
+		if err != nil {
+			return "", err
+		}
+
+		if policy == nil || policy.Check(password) == nil {
+			return password, nil
+		}
+	}
+
+	return "", ErrPolicyNotSatisfiable
+}
+
+func generatePasswordFromCharset(options PasswordGeneratorOptions, charset *CharsetBuilder) (string, error) {
+	random := randomSourceOrDefault(options.Random)
+
+	positionCharsets, err := assignPositionCharsets(options, charset.characters)
+	if err != nil {
+		return "", err
+	}
+
+	password := make([]rune, 0, len(positionCharsets))
+
+	for _, characters := range positionCharsets {
+		avoidRepeats := normalizeAvoidRepeats(options.AvoidRepeats, len(characters))
+
+		character, err := selectValidPasswordChar(random, characters, password, avoidRepeats)
+		if err != nil {
+			return "", err
+		}
+		password = append(password, character)
+	}
+
+	return string(password), nil
+}
+
+// assignPositionCharsets returns the charset to draw from for every position
+// of the password. Positions reserved for minimum character counts are picked
+// uniformly at random and draw only from their own character class.
+func assignPositionCharsets(options PasswordGeneratorOptions, characters []rune) ([][]rune, error) {
+	positions := make([]int, max(options.Length, 0))
+	for i := range positions {
+		positions[i] = i
+	}
+
+	if options.minimumsTotal() > 0 {
+		var err error
+		if positions, err = shuffledIndexes(randomSourceOrDefault(options.Random), len(positions)); err != nil {
+			return nil, err
+		}
+	}
+
+	return layoutPositionCharsets(options, characters, positions)
+}
+
+// layoutPositionCharsets reserves the given positions, in order, for the
+// minimum character counts and leaves the rest to the whole pool.
+func layoutPositionCharsets(options PasswordGeneratorOptions, characters []rune, positions []int) ([][]rune, error) {
+	positionCharsets := make([][]rune, len(positions))
+	for i := range positionCharsets {
+		positionCharsets[i] = characters
+	}
+
+	for _, required := range options.requiredCharsets() {
+		requiredCharacters := NewCharsetBuilder().
+			WithCustom(required.characters).
+			Without(options.excludedCharacters()).
+			characters
+		if required.minimum > 0 && len(requiredCharacters) == 0 {
+			return nil, ErrEmptyCharset
+		}
+
+		for range required.minimum {
+			positionCharsets[positions[0]] = requiredCharacters
+			positions = positions[1:]
+		}
+	}
+
+	return positionCharsets, nil
+}
+
+func shuffledIndexes(random RandomSource, n int) ([]int, error) {
+	indexes := make([]int, n)
+	for i := range indexes {
+		indexes[i] = i
+	}
+
+	for i := n - 1; i > 0; i-- {
+		j, err := randomInt(random, 0, i)
+		if err != nil {
+			return nil, err
+		}
+		indexes[i], indexes[j] = indexes[j], indexes[i]
+	}
+
+	return indexes, nil
+}
+
+func normalizeAvoidRepeats(avoidRepeats, charsetLength int) int {
+	if avoidRepeats >= charsetLength {
+		return charsetLength - 1
+	}
+
+	if avoidRepeats <= 0 {
+		return 0
+	}
+
+	return avoidRepeats
+}
+
+func selectValidPasswordChar(random RandomSource, charset, currentPassword []rune, avoidRepeats int) (rune, error) {
+	for {
+		character, err := pickRandomChar(random, charset)
+		if err != nil {
+			return 0, err
+		}
+
+		if isValidPasswordChar(character, currentPassword, avoidRepeats) {
+			return character, nil
+		}
+	}
+}
+
+func isValidPasswordChar(character rune, password []rune, avoidRepeats int) bool {
+	if avoidRepeats <= 0 {
+		return true
+	}
+
+	recentChars := getPasswordSuffix(password, avoidRepeats)
+	return !slices.Contains(recentChars, character)
+}
+
+func getPasswordSuffix(password []rune, suffixLength int) []rune {
+	if suffixLength >= len(password) {
+		return password
+	}
+
+	return password[len(password)-suffixLength:]
+}
+
+func pickRandomChar(random RandomSource, charset []rune) (rune, error) {
+	if len(charset) == 0 {
+		return 0, ErrCannotSelectFromEmptyCharset
+	}
+
+	randomIndex, err := randomInt(random, 0, len(charset)-1)
+	if err != nil {
+		return 0, err
+	}
+
+	return charset[randomIndex], nil
+}
+
+func randomInt(random RandomSource, min, max int) (int, error) {
+	if min > max {
+		return 0, ErrMinCannotBeGreaterThanMax
+	}
+
+	if min == max {
+		return min, nil
+	}
+
+	diff := max - min + 1
+	n, err := rand.Int(random, big.NewInt(int64(diff)))
+	if err != nil {
+		return 0, err
+	}
+
+	return int(n.Int64()) + min, nil
+}
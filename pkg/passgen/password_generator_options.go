@@ -0,0 +1,120 @@
+package passgen
+
+import "errors"
+
+var (
+	ErrLengthMustBeGreaterThanZero              = errors.New("Length must be greater than 0.")
+	ErrAvoidRepeatsMustBeEqualOrGreaterThanZero = errors.New("Avoid repeats must be greater than or equal to 0.")
+	ErrMinimumMustBeEqualOrGreaterThanZero      = errors.New("Minimum character counts must be greater than or equal to 0.")
+	ErrMinimumForDisabledCharset                = errors.New("Minimum character count is set for a disabled character set.")
+	ErrMinimumsExceedLength                     = errors.New("Sum of minimum character counts cannot be greater than length.")
+)
+
+type PasswordGeneratorOptions struct {
+	Length           int    `json:"length"`
+	Lowercase        bool   `json:"lowercase"`
+	Uppercase        bool   `json:"uppercase"`
+	Numbers          bool   `json:"numbers"`
+	Symbols          bool   `json:"symbols"`
+	SymbolSet        string `json:"symbol_set"`
+	Custom           string `json:"custom"`
+	AvoidRepeats     int    `json:"avoid_repeats"`
+	MinLowercase     int    `json:"min_lowercase"`
+	MinUppercase     int    `json:"min_uppercase"`
+	MinNumbers       int    `json:"min_numbers"`
+	MinSymbols       int    `json:"min_symbols"`
+	MinCustom        int    `json:"min_custom"`
+	ExcludeAmbiguous bool   `json:"exclude_ambiguous"`
+	Exclude          string `json:"exclude"`
+
+	Random RandomSource `json:"-"`
+}
+
+func NewPasswordGeneratorOptions() *PasswordGeneratorOptions {
+	return &PasswordGeneratorOptions{
+		Length:       12,
+		Lowercase:    true,
+		Uppercase:    true,
+		Numbers:      true,
+		Symbols:      false,
+		Custom:       "",
+		AvoidRepeats: 1,
+	}
+}
+
+func (p *PasswordGeneratorOptions) Validate() (bool, error) {
+	if p.Length <= 0 {
+		return false, ErrLengthMustBeGreaterThanZero
+	}
+
+	if p.AvoidRepeats < 0 {
+		return false, ErrAvoidRepeatsMustBeEqualOrGreaterThanZero
+	}
+
+	if err := p.validateMinimums(); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (p *PasswordGeneratorOptions) validateMinimums() error {
+	for _, required := range p.requiredCharsets() {
+		if required.minimum < 0 {
+			return ErrMinimumMustBeEqualOrGreaterThanZero
+		}
+
+		if required.minimum > 0 && !required.enabled {
+			return ErrMinimumForDisabledCharset
+		}
+	}
+
+	if p.minimumsTotal() > p.Length {
+		return ErrMinimumsExceedLength
+	}
+
+	return nil
+}
+
+func (p *PasswordGeneratorOptions) minimumsTotal() int {
+	total := 0
+	for _, required := range p.requiredCharsets() {
+		total += required.minimum
+	}
+
+	return total
+}
+
+// symbolCharacters returns the symbols the password may contain, SymbolSet
+// replaces SymbolChars when it is not empty.
+func (p *PasswordGeneratorOptions) symbolCharacters() string {
+	if p.SymbolSet != "" {
+		return p.SymbolSet
+	}
+
+	return SymbolChars
+}
+
+func (p *PasswordGeneratorOptions) excludedCharacters() string {
+	if p.ExcludeAmbiguous {
+		return p.Exclude + AmbiguousChars
+	}
+
+	return p.Exclude
+}
+
+type requiredCharset struct {
+	characters string
+	enabled    bool
+	minimum    int
+}
+
+func (p *PasswordGeneratorOptions) requiredCharsets() []requiredCharset {
+	return []requiredCharset{
+		{characters: UppercaseChars, enabled: p.Uppercase, minimum: p.MinUppercase},
+		{characters: LowercaseChars, enabled: p.Lowercase, minimum: p.MinLowercase},
+		{characters: NumberChars, enabled: p.Numbers, minimum: p.MinNumbers},
+		{characters: p.symbolCharacters(), enabled: p.Symbols, minimum: p.MinSymbols},
+		{characters: p.Custom, enabled: p.Custom != "", minimum: p.MinCustom},
+	}
+}
@@ -1,4 +1,4 @@
-package internal
+package passgen
 
 import (
 	"testing"
@@ -16,7 +16,6 @@ func TestPasswordGeneratorOptions(t *testing.T) {
 	assert.False(t, options.Symbols)
 	assert.Empty(t, options.Custom)
 	assert.Equal(t, 1, options.AvoidRepeats)
-	assert.False(t, options.QrCode)
 }
 
 func TestValidate(t *testing.T) {
@@ -97,3 +96,89 @@ func TestValidate(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateMinimums(t *testing.T) {
+	tests := []struct {
+		name    string
+		options PasswordGeneratorOptions
+		ErrWant error
+	}{
+		{
+			name: "minimums within length",
+			options: PasswordGeneratorOptions{
+				Length:       12,
+				Lowercase:    true,
+				Uppercase:    true,
+				Numbers:      true,
+				Symbols:      true,
+				MinUppercase: 1,
+				MinNumbers:   1,
+				MinSymbols:   1,
+			},
+			ErrWant: nil,
+		},
+		{
+			name: "minimums equal to length",
+			options: PasswordGeneratorOptions{
+				Length:       4,
+				Lowercase:    true,
+				Numbers:      true,
+				MinLowercase: 2,
+				MinNumbers:   2,
+			},
+			ErrWant: nil,
+		},
+		{
+			name: "minimums exceed length",
+			options: PasswordGeneratorOptions{
+				Length:       4,
+				Lowercase:    true,
+				Numbers:      true,
+				MinLowercase: 3,
+				MinNumbers:   2,
+			},
+			ErrWant: ErrMinimumsExceedLength,
+		},
+		{
+			name: "negative minimum",
+			options: PasswordGeneratorOptions{
+				Length:     8,
+				Numbers:    true,
+				MinNumbers: -1,
+			},
+			ErrWant: ErrMinimumMustBeEqualOrGreaterThanZero,
+		},
+		{
+			name: "minimum for disabled charset",
+			options: PasswordGeneratorOptions{
+				Length:     8,
+				Lowercase:  true,
+				MinSymbols: 1,
+			},
+			ErrWant: ErrMinimumForDisabledCharset,
+		},
+		{
+			name: "minimum for empty custom charset",
+			options: PasswordGeneratorOptions{
+				Length:    8,
+				Lowercase: true,
+				MinCustom: 1,
+			},
+			ErrWant: ErrMinimumForDisabledCharset,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tt.options.Validate()
+
+			if tt.ErrWant != nil {
+				assert.False(t, result)
+				assert.Equal(t, tt.ErrWant, err)
+			} else {
+				assert.True(t, result)
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
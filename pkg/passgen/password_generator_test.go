@@ -0,0 +1,663 @@
+package passgen
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeneratePassword(t *testing.T) {
+	t.Run("should generate password with correct length", func(t *testing.T) {
+		options := PasswordGeneratorOptions{
+			Length:    15,
+			Lowercase: true,
+		}
+
+		password, err := GeneratePassword(options)
+
+		require.NoError(t, err)
+		assert.Len(t, password, options.Length)
+	})
+
+	t.Run("should generate valid UTF-8 from multibyte custom charset", func(t *testing.T) {
+		options := PasswordGeneratorOptions{
+			Length:       16,
+			Custom:       "äöü€🔒",
+			AvoidRepeats: 2,
+		}
+
+		password, err := GeneratePassword(options)
+
+		require.NoError(t, err)
+		assert.True(t, utf8.ValidString(password))
+		assert.Equal(t, options.Length, utf8.RuneCountInString(password))
+		for _, character := range password {
+			assert.Contains(t, options.Custom, string(character))
+		}
+	})
+
+	t.Run("should return error for empty charset", func(t *testing.T) {
+		options := PasswordGeneratorOptions{
+			Length: 10,
+		}
+
+		password, err := GeneratePassword(options)
+
+		assert.Empty(t, password)
+		assert.Equal(t, ErrEmptyCharset, err)
+	})
+	t.Run("should generate valid password with avoid repeats", func(t *testing.T) {
+		options := PasswordGeneratorOptions{
+			Length:       10,
+			Lowercase:    true,
+			Uppercase:    true,
+			AvoidRepeats: 2,
+		}
+
+		password, err := GeneratePassword(options)
+
+		require.NoError(t, err)
+		assert.Len(t, password, 10)
+	})
+
+	t.Run("should satisfy minimum character counts", func(t *testing.T) {
+		options := PasswordGeneratorOptions{
+			Length:       8,
+			Lowercase:    true,
+			Uppercase:    true,
+			Numbers:      true,
+			Symbols:      true,
+			Custom:       "xyz",
+			AvoidRepeats: 1,
+			MinUppercase: 2,
+			MinNumbers:   2,
+			MinSymbols:   1,
+			MinCustom:    1,
+		}
+
+		for range 100 {
+			password, err := GeneratePassword(options)
+
+			require.NoError(t, err)
+			assert.GreaterOrEqual(t, countCharsIn(password, UppercaseChars), 2)
+			assert.GreaterOrEqual(t, countCharsIn(password, NumberChars), 2)
+			assert.GreaterOrEqual(t, countCharsIn(password, SymbolChars), 1)
+			assert.GreaterOrEqual(t, countCharsIn(password, "xyz"), 1)
+		}
+	})
+
+	t.Run("should fill whole password from minimums", func(t *testing.T) {
+		options := PasswordGeneratorOptions{
+			Length:       6,
+			Lowercase:    true,
+			Numbers:      true,
+			AvoidRepeats: 3,
+			MinLowercase: 3,
+			MinNumbers:   3,
+		}
+
+		password, err := GeneratePassword(options)
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, countCharsIn(password, LowercaseChars))
+		assert.Equal(t, 3, countCharsIn(password, NumberChars))
+	})
+
+	t.Run("should return error when minimums exceed length", func(t *testing.T) {
+		options := PasswordGeneratorOptions{
+			Length:     2,
+			Numbers:    true,
+			MinNumbers: 3,
+		}
+
+		password, err := GeneratePassword(options)
+
+		assert.Empty(t, password)
+		assert.Equal(t, ErrMinimumsExceedLength, err)
+	})
+
+	t.Run("should never use excluded characters", func(t *testing.T) {
+		options := PasswordGeneratorOptions{
+			Length:           64,
+			Lowercase:        true,
+			Uppercase:        true,
+			Numbers:          true,
+			Symbols:          true,
+			Exclude:          "abc",
+			ExcludeAmbiguous: true,
+			MinNumbers:       4,
+			MinSymbols:       4,
+		}
+
+		password, err := GeneratePassword(options)
+
+		require.NoError(t, err)
+		assert.False(t, strings.ContainsAny(password, "abc"+AmbiguousChars))
+	})
+
+	t.Run("should return error when exclusions empty the charset", func(t *testing.T) {
+		options := PasswordGeneratorOptions{Length: 8, Numbers: true, Exclude: NumberChars}
+
+		password, err := GeneratePassword(options)
+
+		assert.Empty(t, password)
+		assert.Equal(t, ErrEmptyCharset, err)
+	})
+
+	t.Run("should return error when exclusions empty a required class", func(t *testing.T) {
+		options := PasswordGeneratorOptions{
+			Length:     8,
+			Lowercase:  true,
+			Numbers:    true,
+			Exclude:    NumberChars,
+			MinNumbers: 1,
+		}
+
+		password, err := GeneratePassword(options)
+
+		assert.Empty(t, password)
+		assert.Equal(t, ErrEmptyCharset, err)
+	})
+
+	t.Run("should handle zero length password", func(t *testing.T) {
+		options := PasswordGeneratorOptions{Length: 0, Lowercase: true}
+
+		password, err := GeneratePassword(options)
+
+		require.NoError(t, err)
+		assert.Empty(t, password)
+	})
+}
+
+func TestGeneratePasswords(t *testing.T) {
+	t.Run("should generate requested number of passwords", func(t *testing.T) {
+		options := PasswordGeneratorOptions{Length: 12, Lowercase: true, Numbers: true, MinNumbers: 2}
+
+		passwords, err := GeneratePasswords(options, 50)
+
+		require.NoError(t, err)
+		assert.Len(t, passwords, 50)
+		for _, password := range passwords {
+			assert.Len(t, password, 12)
+			assert.GreaterOrEqual(t, countCharsIn(password, NumberChars), 2)
+		}
+	})
+
+	t.Run("should return error for non-positive count", func(t *testing.T) {
+		options := PasswordGeneratorOptions{Length: 12, Lowercase: true}
+
+		passwords, err := GeneratePasswords(options, 0)
+
+		assert.Nil(t, passwords)
+		assert.Equal(t, ErrCountMustBeGreaterThanZero, err)
+	})
+
+	t.Run("should return error for empty charset", func(t *testing.T) {
+		passwords, err := GeneratePasswords(PasswordGeneratorOptions{Length: 12}, 3)
+
+		assert.Nil(t, passwords)
+		assert.Equal(t, ErrEmptyCharset, err)
+	})
+}
+
+func TestGenerateUniquePasswords(t *testing.T) {
+	t.Run("should not repeat passwords", func(t *testing.T) {
+		options := PasswordGeneratorOptions{Length: 2, Custom: "abcd", AvoidRepeats: 0}
+
+		passwords, err := GenerateUniquePasswords(options, 16)
+
+		require.NoError(t, err)
+		assert.Len(t, passwords, 16)
+		assert.ElementsMatch(t, []string{
+			"aa", "ab", "ac", "ad", "ba", "bb", "bc", "bd",
+			"ca", "cb", "cc", "cd", "da", "db", "dc", "dd",
+		}, passwords)
+	})
+
+	t.Run("should return error when options cannot produce enough passwords", func(t *testing.T) {
+		options := PasswordGeneratorOptions{Length: 2, Custom: "abcd", AvoidRepeats: 1}
+
+		passwords, err := GenerateUniquePasswords(options, 13)
+
+		assert.Nil(t, passwords)
+		assert.Equal(t, ErrNotEnoughUniquePasswords, err)
+	})
+}
+
+func TestPasswordByteLengthRange(t *testing.T) {
+	t.Run("ASCII characters", func(t *testing.T) {
+		shortest, longest := PasswordByteLengthRange(PasswordGeneratorOptions{Length: 12, Lowercase: true, Symbols: true})
+
+		assert.Equal(t, 12, shortest)
+		assert.Equal(t, 12, longest)
+	})
+
+	t.Run("multi-byte characters", func(t *testing.T) {
+		shortest, longest := PasswordByteLengthRange(PasswordGeneratorOptions{Length: 10, Numbers: true, Custom: "äö€"})
+
+		assert.Equal(t, 10, shortest)
+		assert.Equal(t, 30, longest)
+	})
+
+	t.Run("empty charset", func(t *testing.T) {
+		shortest, longest := PasswordByteLengthRange(PasswordGeneratorOptions{Length: 10})
+
+		assert.Zero(t, shortest)
+		assert.Zero(t, longest)
+	})
+}
+
+func TestGenerateBatch(t *testing.T) {
+	t.Run("should stop when unique results run out", func(t *testing.T) {
+		calls := 0
+		passwords, err := GenerateBatch(2, true, func() (string, error) {
+			calls++
+			return "same", nil
+		})
+
+		assert.Nil(t, passwords)
+		assert.Equal(t, ErrNotEnoughUniquePasswords, err)
+		assert.Equal(t, 2*maxUniqueAttemptsPerPassword, calls)
+	})
+
+	t.Run("should keep repeated results when not unique", func(t *testing.T) {
+		passwords, err := GenerateBatch(3, false, func() (string, error) {
+			return "same", nil
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"same", "same", "same"}, passwords)
+	})
+
+	t.Run("should return error for zero count", func(t *testing.T) {
+		_, err := GenerateBatch(0, false, func() (string, error) { return "", nil })
+
+		assert.Equal(t, ErrCountMustBeGreaterThanZero, err)
+	})
+}
+
+func countCharsIn(password, charset string) int {
+	count := 0
+	for _, character := range password {
+		if strings.ContainsRune(charset, character) {
+			count++
+		}
+	}
+
+	return count
+}
+
+func TestAssignPositionCharsets(t *testing.T) {
+	t.Run("should use full charset without minimums", func(t *testing.T) {
+		options := PasswordGeneratorOptions{Length: 4, Lowercase: true}
+
+		positionCharsets, err := assignPositionCharsets(options, []rune("abc"))
+
+		require.NoError(t, err)
+		assert.Equal(t, [][]rune{[]rune("abc"), []rune("abc"), []rune("abc"), []rune("abc")}, positionCharsets)
+	})
+
+	t.Run("should reserve positions for minimums", func(t *testing.T) {
+		options := PasswordGeneratorOptions{
+			Length:     5,
+			Lowercase:  true,
+			Numbers:    true,
+			MinNumbers: 2,
+		}
+
+		positionCharsets, err := assignPositionCharsets(options, []rune("abc123"))
+
+		require.NoError(t, err)
+		assert.Len(t, positionCharsets, 5)
+
+		reserved := 0
+		for _, characters := range positionCharsets {
+			if string(characters) == NumberChars {
+				reserved++
+			}
+		}
+		assert.Equal(t, 2, reserved)
+	})
+}
+
+func TestShuffledIndexes(t *testing.T) {
+	indexes, err := shuffledIndexes(CryptoRandom, 10)
+
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, indexes)
+}
+
+func TestNormalizeAvoidRepeats(t *testing.T) {
+	tests := []struct {
+		name          string
+		avoidRepeats  int
+		charsetLength int
+		expected      int
+	}{
+		{
+			name:          "avoid repeats less than charset length",
+			avoidRepeats:  3,
+			charsetLength: 10,
+			expected:      3,
+		},
+		{
+			name:          "avoid repeats equal to charset length",
+			avoidRepeats:  10,
+			charsetLength: 10,
+			expected:      9,
+		},
+		{
+			name:          "avoid repeats greater than charset length",
+			avoidRepeats:  15,
+			charsetLength: 10,
+			expected:      9,
+		},
+		{
+			name:          "zero avoid repeats",
+			avoidRepeats:  0,
+			charsetLength: 10,
+			expected:      0,
+		},
+		{
+			name:          "negative avoid repeats",
+			avoidRepeats:  -5,
+			charsetLength: 10,
+			expected:      0,
+		},
+		{
+			name:          "charset length 1",
+			avoidRepeats:  1,
+			charsetLength: 1,
+			expected:      0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := normalizeAvoidRepeats(tt.avoidRepeats, tt.charsetLength)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestSelectValidPasswordChar(t *testing.T) {
+	t.Run("should select char from charset", func(t *testing.T) {
+		charset := []rune("abc")
+		currentPassword := []rune("")
+		avoidRepeats := 0
+
+		char, err := selectValidPasswordChar(CryptoRandom, charset, currentPassword, avoidRepeats)
+
+		require.NoError(t, err)
+		assert.Contains(t, charset, char)
+	})
+
+	t.Run("should respect avoid repeats constraint", func(t *testing.T) {
+		charset := []rune("abcdef")
+		currentPassword := []rune("ab")
+		avoidRepeats := 2
+
+		char, err := selectValidPasswordChar(CryptoRandom, charset, currentPassword, avoidRepeats)
+
+		require.NoError(t, err)
+		assert.Contains(t, charset, char)
+		assert.NotContains(t, currentPassword, char)
+	})
+
+	t.Run("should select multibyte char from charset", func(t *testing.T) {
+		charset := []rune("äöü€")
+		currentPassword := []rune("äöü")
+		avoidRepeats := 3
+
+		char, err := selectValidPasswordChar(CryptoRandom, charset, currentPassword, avoidRepeats)
+
+		require.NoError(t, err)
+		assert.Equal(t, '€', char)
+	})
+
+	t.Run("should return error for empty charset", func(t *testing.T) {
+		charset := []rune("")
+		currentPassword := []rune("")
+		avoidRepeats := 0
+
+		char, err := selectValidPasswordChar(CryptoRandom, charset, currentPassword, avoidRepeats)
+
+		assert.Empty(t, char)
+		assert.Error(t, err)
+		assert.Equal(t, ErrCannotSelectFromEmptyCharset, err)
+	})
+
+	t.Run("should work with no avoid repeats", func(t *testing.T) {
+		charset := []rune("a")
+		currentPassword := []rune("aaaa")
+		avoidRepeats := 0
+
+		char, err := selectValidPasswordChar(CryptoRandom, charset, currentPassword, avoidRepeats)
+
+		require.NoError(t, err)
+		assert.Equal(t, 'a', char)
+	})
+}
+
+func TestIsValidPasswordChar(t *testing.T) {
+	tests := []struct {
+		name         string
+		character    rune
+		password     string
+		avoidRepeats int
+		expected     bool
+	}{
+		{
+			name:         "valid char with no restrictions",
+			character:    'a',
+			password:     "bcdef",
+			avoidRepeats: 0,
+			expected:     true,
+		},
+		{
+			name:         "valid char not in recent chars",
+			character:    'd',
+			password:     "abc",
+			avoidRepeats: 2,
+			expected:     true,
+		},
+		{
+			name:         "invalid char in recent chars",
+			character:    'c',
+			password:     "abc",
+			avoidRepeats: 2,
+			expected:     false,
+		},
+		{
+			name:         "valid char with avoid repeats larger than password",
+			character:    'd',
+			password:     "abc",
+			avoidRepeats: 5,
+			expected:     true,
+		},
+		{
+			name:         "invalid char with avoid repeats larger than password",
+			character:    'a',
+			password:     "abc",
+			avoidRepeats: 5,
+			expected:     false,
+		},
+		{
+			name:         "invalid multibyte char in recent chars",
+			character:    '€',
+			password:     "äöü€",
+			avoidRepeats: 1,
+			expected:     false,
+		},
+		{
+			name:         "valid multibyte char not in recent chars",
+			character:    'ä',
+			password:     "äöü€",
+			avoidRepeats: 3,
+			expected:     true,
+		},
+		{
+			name:         "zero avoid repeats should allow any char",
+			character:    'a',
+			password:     "aaa",
+			avoidRepeats: 0,
+			expected:     true,
+		},
+		{
+			name:         "negative avoid repeats should allow any char",
+			character:    'a',
+			password:     "aaa",
+			avoidRepeats: -1,
+			expected:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := isValidPasswordChar(tt.character, []rune(tt.password), tt.avoidRepeats)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestPickRandomPasswordChar(t *testing.T) {
+	t.Run("should pick char from single character charset", func(t *testing.T) {
+		charset := []rune("a")
+
+		char, err := pickRandomChar(CryptoRandom, charset)
+
+		require.NoError(t, err)
+		assert.Equal(t, 'a', char)
+	})
+
+	t.Run("should pick char from multi character charset", func(t *testing.T) {
+		charset := []rune("abcdef")
+
+		char, err := pickRandomChar(CryptoRandom, charset)
+
+		require.NoError(t, err)
+		assert.Contains(t, charset, char)
+	})
+
+	t.Run("should pick whole multibyte char", func(t *testing.T) {
+		charset := []rune("🔒🔑")
+
+		char, err := pickRandomChar(CryptoRandom, charset)
+
+		require.NoError(t, err)
+		assert.Contains(t, charset, char)
+	})
+
+	t.Run("should return error for empty charset", func(t *testing.T) {
+		charset := []rune("")
+
+		char, err := pickRandomChar(CryptoRandom, charset)
+
+		assert.Empty(t, char)
+		assert.Error(t, err)
+		assert.Equal(t, ErrCannotSelectFromEmptyCharset, err)
+	})
+}
+
+func TestRandomInt(t *testing.T) {
+	t.Run("should return value in range", func(t *testing.T) {
+		min, max := 5, 10
+
+		result, err := randomInt(CryptoRandom, min, max)
+
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, result, min)
+		assert.LessOrEqual(t, result, max)
+	})
+
+	t.Run("should handle single value range", func(t *testing.T) {
+		min, max := 5, 5
+
+		result, err := randomInt(CryptoRandom, min, max)
+
+		require.NoError(t, err)
+		assert.Equal(t, 5, result)
+	})
+
+	t.Run("should return error when min > max", func(t *testing.T) {
+		min, max := 10, 5
+
+		result, err := randomInt(CryptoRandom, min, max)
+
+		assert.Zero(t, result)
+		assert.Error(t, err)
+		assert.Equal(t, ErrMinCannotBeGreaterThanMax, err)
+	})
+
+	t.Run("should handle negative values", func(t *testing.T) {
+		min, max := -10, -5
+
+		result, err := randomInt(CryptoRandom, min, max)
+
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, result, min)
+		assert.LessOrEqual(t, result, max)
+	})
+}
+
+func TestGetPasswordSuffix(t *testing.T) {
+	tests := []struct {
+		name         string
+		password     string
+		suffixLength int
+		expected     string
+	}{
+		{
+			name:         "suffix shorter than password",
+			password:     "abcdef",
+			suffixLength: 3,
+			expected:     "def",
+		},
+		{
+			name:         "suffix equal to password length",
+			password:     "abc",
+			suffixLength: 3,
+			expected:     "abc",
+		},
+		{
+			name:         "suffix longer than password",
+			password:     "abc",
+			suffixLength: 5,
+			expected:     "abc",
+		},
+		{
+			name:         "empty password",
+			password:     "",
+			suffixLength: 3,
+			expected:     "",
+		},
+		{
+			name:         "zero suffix length",
+			password:     "abcdef",
+			suffixLength: 0,
+			expected:     "",
+		},
+		{
+			name:         "multibyte password",
+			password:     "äöü€",
+			suffixLength: 2,
+			expected:     "ü€",
+		},
+		{
+			name:         "single character password",
+			password:     "a",
+			suffixLength: 1,
+			expected:     "a",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := getPasswordSuffix([]rune(tt.password), tt.suffixLength)
+			assert.Equal(t, tt.expected, string(result))
+		})
+	}
+}
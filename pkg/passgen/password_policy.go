@@ -0,0 +1,171 @@
+package passgen
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	PolicyClassLowercase = "lowercase"
+	PolicyClassUppercase = "uppercase"
+	PolicyClassNumbers   = "numbers"
+	PolicyClassSymbols   = "symbols"
+)
+
+var (
+	ErrPolicyUnknownClass                       = errors.New("Policy required classes must be lowercase, uppercase, numbers or symbols.")
+	ErrPolicyLengthRange                        = errors.New("Policy minimum length cannot be greater than maximum length.")
+	ErrPolicyLength                             = errors.New("Password length is outside of the policy range.")
+	ErrPolicyForbiddenCharacter                 = errors.New("Password contains a character forbidden by the policy.")
+	ErrPolicyForbiddenStart                     = errors.New("Password starts with a character forbidden by the policy.")
+	ErrPolicyMissingClass                       = errors.New("Password is missing a character class required by the policy.")
+	ErrPolicyNotSatisfiable                     = errors.New("Could not generate a password satisfying the policy.")
+	ErrPolicyLengthMustBeEqualOrGreaterThanZero = errors.New("Policy lengths must be greater than or equal to 0.")
+)
+
+// PasswordPolicy describes the password rules of a target system, e.g.
+//
+//	{"max_length": 16, "allowed_symbols": "!#$", "forbidden_start": "0123456789", "require": ["numbers", "symbols"]}
+type PasswordPolicy struct {
+	MinLength      int      `json:"min_length"`
+	MaxLength      int      `json:"max_length"`
+	AllowedSymbols string   `json:"allowed_symbols"`
+	Forbidden      string   `json:"forbidden"`
+	ForbiddenStart string   `json:"forbidden_start"`
+	Require        []string `json:"require"`
+}
+
+// ParsePasswordPolicy decodes and validates a JSON policy. Unknown fields are
+// rejected so typos do not silently weaken the policy.
+func ParsePasswordPolicy(data []byte) (*PasswordPolicy, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	policy := &PasswordPolicy{}
+	if err := decoder.Decode(policy); err != nil {
+		return nil, err
+	}
+
+	if _, err := policy.Validate(); err != nil {
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+func (p *PasswordPolicy) Validate() (bool, error) {
+	if p.MinLength < 0 || p.MaxLength < 0 {
+		return false, ErrPolicyLengthMustBeEqualOrGreaterThanZero
+	}
+
+	if p.MaxLength > 0 && p.MinLength > p.MaxLength {
+		return false, ErrPolicyLengthRange
+	}
+
+	for _, class := range p.Require {
+		if _, ok := p.classCharacters(class); !ok {
+			return false, ErrPolicyUnknownClass
+		}
+	}
+
+	return true, nil
+}
+
+// Apply adjusts the options so generated passwords follow the policy by
+// construction: the length is clamped to the allowed range, the symbols are
+// limited to the allowed ones, forbidden characters are excluded and every
+// required class gets at least one character.
+func (p *PasswordPolicy) Apply(options *PasswordGeneratorOptions) {
+	if p.MinLength > 0 && options.Length < p.MinLength {
+		options.Length = p.MinLength
+	}
+
+	if p.MaxLength > 0 && options.Length > p.MaxLength {
+		options.Length = p.MaxLength
+	}
+
+	// Allowed symbols outside of SymbolChars only get into the pool this way,
+	// the other symbols are excluded so custom characters cannot add them.
+	if p.AllowedSymbols != "" {
+		options.SymbolSet = p.AllowedSymbols
+	}
+	options.Exclude += p.Forbidden + p.disallowedSymbols()
+
+	for _, class := range p.Require {
+		switch class {
+		case PolicyClassLowercase:
+			options.Lowercase, options.MinLowercase = true, max(options.MinLowercase, 1)
+		case PolicyClassUppercase:
+			options.Uppercase, options.MinUppercase = true, max(options.MinUppercase, 1)
+		case PolicyClassNumbers:
+			options.Numbers, options.MinNumbers = true, max(options.MinNumbers, 1)
+		case PolicyClassSymbols:
+			options.Symbols, options.MinSymbols = true, max(options.MinSymbols, 1)
+		}
+	}
+}
+
+// Check reports the first rule of the policy the password violates.
+func (p *PasswordPolicy) Check(password string) error {
+	length := utf8.RuneCountInString(password)
+	if err := p.CheckLength(length); err != nil {
+		return err
+	}
+
+	if strings.ContainsAny(password, p.Forbidden+p.disallowedSymbols()) {
+		return ErrPolicyForbiddenCharacter
+	}
+
+	if first, _ := utf8.DecodeRuneInString(password); length > 0 && strings.ContainsRune(p.ForbiddenStart, first) {
+		return ErrPolicyForbiddenStart
+	}
+
+	for _, class := range p.Require {
+		characters, _ := p.classCharacters(class)
+		if !strings.ContainsAny(password, characters) {
+			return ErrPolicyMissingClass
+		}
+	}
+
+	return nil
+}
+
+// CheckLength reports whether the length is outside of the policy range. Apply
+// clamps the length instead, callers that must not change a length chosen by
+// the user check it first.
+func (p *PasswordPolicy) CheckLength(length int) error {
+	if length < p.MinLength || (p.MaxLength > 0 && length > p.MaxLength) {
+		return ErrPolicyLength
+	}
+
+	return nil
+}
+
+func (p *PasswordPolicy) disallowedSymbols() string {
+	if p.AllowedSymbols == "" {
+		return ""
+	}
+
+	return NewCharsetBuilder().WithSymbols().Without(p.AllowedSymbols).Characters()
+}
+
+func (p *PasswordPolicy) classCharacters(class string) (string, bool) {
+	switch class {
+	case PolicyClassLowercase:
+		return LowercaseChars, true
+	case PolicyClassUppercase:
+		return UppercaseChars, true
+	case PolicyClassNumbers:
+		return NumberChars, true
+	case PolicyClassSymbols:
+		if p.AllowedSymbols != "" {
+			return p.AllowedSymbols, true
+		}
+		return SymbolChars, true
+	default:
+		return "", false
+	}
+}
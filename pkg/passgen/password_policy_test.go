@@ -0,0 +1,161 @@
+package passgen
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePasswordPolicy(t *testing.T) {
+	t.Run("valid policy", func(t *testing.T) {
+		policy, err := ParsePasswordPolicy([]byte(`{"min_length": 8, "max_length": 16, "allowed_symbols": "!#", "forbidden": "\"'", "forbidden_start": "0123456789", "require": ["numbers", "symbols"]}`))
+
+		require.NoError(t, err)
+		assert.Equal(t, &PasswordPolicy{
+			MinLength:      8,
+			MaxLength:      16,
+			AllowedSymbols: "!#",
+			Forbidden:      `"'`,
+			ForbiddenStart: "0123456789",
+			Require:        []string{PolicyClassNumbers, PolicyClassSymbols},
+		}, policy)
+	})
+
+	t.Run("unknown field", func(t *testing.T) {
+		_, err := ParsePasswordPolicy([]byte(`{"max_lenght": 16}`))
+
+		assert.ErrorContains(t, err, "max_lenght")
+	})
+
+	t.Run("invalid policy", func(t *testing.T) {
+		_, err := ParsePasswordPolicy([]byte(`{"require": ["emoji"]}`))
+
+		assert.Equal(t, ErrPolicyUnknownClass, err)
+	})
+}
+
+func TestPasswordPolicyValidate(t *testing.T) {
+	tests := []struct {
+		name           string
+		policy         PasswordPolicy
+		expectedResult bool
+		ErrWant        error
+	}{
+		{name: "empty policy", policy: PasswordPolicy{}, expectedResult: true},
+		{name: "valid range", policy: PasswordPolicy{MinLength: 8, MaxLength: 8}, expectedResult: true},
+		{name: "negative length", policy: PasswordPolicy{MaxLength: -1}, ErrWant: ErrPolicyLengthMustBeEqualOrGreaterThanZero},
+		{name: "inverted range", policy: PasswordPolicy{MinLength: 10, MaxLength: 8}, ErrWant: ErrPolicyLengthRange},
+		{name: "unknown class", policy: PasswordPolicy{Require: []string{"custom"}}, ErrWant: ErrPolicyUnknownClass},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tt.policy.Validate()
+
+			assert.Equal(t, tt.expectedResult, result)
+			assert.Equal(t, tt.ErrWant, err)
+		})
+	}
+}
+
+func TestPasswordPolicyApply(t *testing.T) {
+	options := PasswordGeneratorOptions{Length: 32, Lowercase: true}
+	policy := &PasswordPolicy{MaxLength: 16, AllowedSymbols: "!#", Forbidden: "xyz", Require: []string{PolicyClassSymbols, PolicyClassNumbers}}
+
+	policy.Apply(&options)
+
+	assert.Equal(t, 16, options.Length)
+	assert.True(t, options.Symbols)
+	assert.Equal(t, 1, options.MinSymbols)
+	assert.True(t, options.Numbers)
+	assert.Equal(t, 1, options.MinNumbers)
+	assert.False(t, options.Uppercase)
+
+	charset := NewCharsetBuilderFromPasswordGeneratorOptions(options)
+	assert.Equal(t, "abcdefghijklmnopqrstuvw0123456789!#", charset.Characters())
+
+	t.Run("allowed symbols are the symbol class", func(t *testing.T) {
+		options := PasswordGeneratorOptions{Length: 8, Lowercase: true}
+		policy := &PasswordPolicy{AllowedSymbols: "€£!", Require: []string{PolicyClassSymbols}}
+
+		policy.Apply(&options)
+
+		assert.Equal(t, "€£!", options.SymbolSet)
+		assert.Equal(t, LowercaseChars+"€£!", NewCharsetBuilderFromPasswordGeneratorOptions(options).Characters())
+	})
+}
+
+func TestPasswordPolicyCheckLength(t *testing.T) {
+	policy := &PasswordPolicy{MinLength: 6, MaxLength: 10}
+
+	assert.Equal(t, ErrPolicyLength, policy.CheckLength(5))
+	assert.NoError(t, policy.CheckLength(6))
+	assert.NoError(t, policy.CheckLength(10))
+	assert.Equal(t, ErrPolicyLength, policy.CheckLength(11))
+	assert.NoError(t, (&PasswordPolicy{}).CheckLength(100))
+}
+
+func TestPasswordPolicyCheck(t *testing.T) {
+	policy := &PasswordPolicy{MinLength: 6, MaxLength: 10, AllowedSymbols: "!#", Forbidden: "q", ForbiddenStart: "0123456789", Require: []string{PolicyClassNumbers, PolicyClassSymbols}}
+
+	tests := []struct {
+		password string
+		expected error
+	}{
+		{"abc1!def", nil},
+		{"ab1!", ErrPolicyLength},
+		{"abc1!defghij", ErrPolicyLength},
+		{"abq1!def", ErrPolicyForbiddenCharacter},
+		{"abc1$def", ErrPolicyForbiddenCharacter},
+		{"1abc!def", ErrPolicyForbiddenStart},
+		{"abcd!def", ErrPolicyMissingClass},
+		{"abc1edef", ErrPolicyMissingClass},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.password, func(t *testing.T) {
+			assert.Equal(t, tt.expected, policy.Check(tt.password))
+		})
+	}
+}
+
+func TestGeneratePolicyPasswords(t *testing.T) {
+	policy := &PasswordPolicy{MaxLength: 10, AllowedSymbols: "!#", ForbiddenStart: "0123456789!#", Require: []string{PolicyClassNumbers, PolicyClassSymbols}}
+	options := PasswordGeneratorOptions{Length: 24, Lowercase: true, Uppercase: true, AvoidRepeats: 1}
+
+	passwords, err := GeneratePolicyPasswords(options, policy, 50, true)
+
+	require.NoError(t, err)
+	assert.Len(t, passwords, 50)
+	for _, password := range passwords {
+		assert.NoError(t, policy.Check(password), password)
+		assert.Equal(t, 10, utf8.RuneCountInString(password))
+		assert.False(t, strings.ContainsAny(password[:1], "0123456789!#"))
+	}
+
+	t.Run("allowed symbols outside of the default symbols", func(t *testing.T) {
+		policy := &PasswordPolicy{AllowedSymbols: "€", Require: []string{PolicyClassSymbols}}
+
+		passwords, err := GeneratePolicyPasswords(PasswordGeneratorOptions{Length: 6, AvoidRepeats: 0}, policy, 1, false)
+
+		require.NoError(t, err)
+		assert.Equal(t, "€€€€€€", passwords[0])
+	})
+
+	t.Run("unsatisfiable policy", func(t *testing.T) {
+		policy := &PasswordPolicy{ForbiddenStart: NumberChars}
+
+		_, err := GeneratePolicyPasswords(PasswordGeneratorOptions{Length: 4, Numbers: true}, policy, 1, false)
+
+		assert.Equal(t, ErrPolicyNotSatisfiable, err)
+	})
+
+	t.Run("invalid policy", func(t *testing.T) {
+		_, err := GeneratePolicyPasswords(options, &PasswordPolicy{MinLength: 5, MaxLength: 4}, 1, false)
+
+		assert.Equal(t, ErrPolicyLengthRange, err)
+	})
+}
@@ -0,0 +1,67 @@
+package passgen
+
+import (
+	"slices"
+	"unicode"
+)
+
+const (
+	PronounceableConsonants = "bcdfghjklmnprstvwz"
+	PronounceableVowels     = "aeiou"
+)
+
+// GeneratePronounceable builds a password from alternating consonants and
+// vowels, optionally capitalizing one letter and inserting digits at random
+// positions.
+func GeneratePronounceable(options PronounceableGeneratorOptions) (string, error) {
+	if options.Digits > options.Length {
+		return "", ErrDigitsCannotExceedLength
+	}
+
+	random := randomSourceOrDefault(options.Random)
+	letters := max(options.Length-max(options.Digits, 0), 0)
+	password := make([]rune, 0, max(options.Length, 0))
+
+	vowel, err := randomInt(random, 0, 1)
+	if err != nil {
+		return "", err
+	}
+
+	consonants, vowels := []rune(PronounceableConsonants), []rune(PronounceableVowels)
+	for i := range letters {
+		charset := consonants
+		if (i+vowel)%2 == 1 {
+			charset = vowels
+		}
+
+		character, err := pickRandomChar(random, charset)
+		if err != nil {
+			return "", err
+		}
+		password = append(password, character)
+	}
+
+	if options.Capitalize && letters > 0 {
+		index, err := randomInt(random, 0, letters-1)
+		if err != nil {
+			return "", err
+		}
+		password[index] = unicode.ToUpper(password[index])
+	}
+
+	digits := []rune(NumberChars)
+	for range max(options.Digits, 0) {
+		digit, err := pickRandomChar(random, digits)
+		if err != nil {
+			return "", err
+		}
+
+		index, err := randomInt(random, 0, len(password))
+		if err != nil {
+			return "", err
+		}
+		password = slices.Insert(password, index, digit)
+	}
+
+	return string(password), nil
+}
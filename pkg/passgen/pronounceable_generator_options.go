@@ -0,0 +1,40 @@
+package passgen
+
+import "errors"
+
+var (
+	ErrDigitsMustBeEqualOrGreaterThanZero = errors.New("Digits must be greater than or equal to 0.")
+	ErrDigitsCannotExceedLength           = errors.New("Digits cannot be greater than length.")
+)
+
+type PronounceableGeneratorOptions struct {
+	Length     int  `json:"length"`
+	Capitalize bool `json:"capitalize"`
+	Digits     int  `json:"digits"`
+
+	Random RandomSource `json:"-"`
+}
+
+func NewPronounceableGeneratorOptions() *PronounceableGeneratorOptions {
+	return &PronounceableGeneratorOptions{
+		Length:     12,
+		Capitalize: false,
+		Digits:     0,
+	}
+}
+
+func (p *PronounceableGeneratorOptions) Validate() (bool, error) {
+	if p.Length <= 0 {
+		return false, ErrLengthMustBeGreaterThanZero
+	}
+
+	if p.Digits < 0 {
+		return false, ErrDigitsMustBeEqualOrGreaterThanZero
+	}
+
+	if p.Digits > p.Length {
+		return false, ErrDigitsCannotExceedLength
+	}
+
+	return true, nil
+}
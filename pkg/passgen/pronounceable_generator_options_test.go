@@ -0,0 +1,38 @@
+package passgen
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPronounceableGeneratorOptions(t *testing.T) {
+	options := NewPronounceableGeneratorOptions()
+
+	assert.Equal(t, 12, options.Length)
+	assert.False(t, options.Capitalize)
+	assert.Zero(t, options.Digits)
+}
+
+func TestPronounceableGeneratorOptionsValidate(t *testing.T) {
+	tests := []struct {
+		name           string
+		options        PronounceableGeneratorOptions
+		expectedResult bool
+		ErrWant        error
+	}{
+		{name: "valid options", options: PronounceableGeneratorOptions{Length: 10, Digits: 2}, expectedResult: true},
+		{name: "zero length", options: PronounceableGeneratorOptions{Length: 0}, ErrWant: ErrLengthMustBeGreaterThanZero},
+		{name: "negative digits", options: PronounceableGeneratorOptions{Length: 8, Digits: -1}, ErrWant: ErrDigitsMustBeEqualOrGreaterThanZero},
+		{name: "digits exceed length", options: PronounceableGeneratorOptions{Length: 3, Digits: 4}, ErrWant: ErrDigitsCannotExceedLength},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tt.options.Validate()
+
+			assert.Equal(t, tt.expectedResult, result)
+			assert.Equal(t, tt.ErrWant, err)
+		})
+	}
+}
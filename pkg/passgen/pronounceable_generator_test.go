@@ -0,0 +1,73 @@
+package passgen
+
+import (
+	"strings"
+	"testing"
+	"unicode"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeneratePronounceable(t *testing.T) {
+	t.Run("should alternate consonants and vowels", func(t *testing.T) {
+		options := PronounceableGeneratorOptions{Length: 12}
+
+		password, err := GeneratePronounceable(options)
+
+		require.NoError(t, err)
+		assert.Len(t, password, 12)
+
+		startsWithVowel := strings.ContainsRune(PronounceableVowels, rune(password[0]))
+		for i, character := range password {
+			expected := PronounceableConsonants
+			if (i%2 == 1) != startsWithVowel {
+				expected = PronounceableVowels
+			}
+			assert.Contains(t, expected, string(character), password)
+		}
+	})
+
+	t.Run("should capitalize one letter", func(t *testing.T) {
+		options := PronounceableGeneratorOptions{Length: 10, Capitalize: true}
+
+		password, err := GeneratePronounceable(options)
+
+		require.NoError(t, err)
+		upper := 0
+		for _, character := range password {
+			if unicode.IsUpper(character) {
+				upper++
+			}
+		}
+		assert.Equal(t, 1, upper)
+	})
+
+	t.Run("should insert digits", func(t *testing.T) {
+		options := PronounceableGeneratorOptions{Length: 10, Digits: 3}
+
+		password, err := GeneratePronounceable(options)
+
+		require.NoError(t, err)
+		assert.Len(t, password, 10)
+		assert.Equal(t, 3, countCharsIn(password, NumberChars))
+	})
+
+	t.Run("should generate only digits", func(t *testing.T) {
+		options := PronounceableGeneratorOptions{Length: 4, Digits: 4, Capitalize: true}
+
+		password, err := GeneratePronounceable(options)
+
+		require.NoError(t, err)
+		assert.Equal(t, 4, countCharsIn(password, NumberChars))
+	})
+
+	t.Run("should return error when digits exceed length", func(t *testing.T) {
+		options := PronounceableGeneratorOptions{Length: 4, Digits: 5}
+
+		password, err := GeneratePronounceable(options)
+
+		assert.Empty(t, password)
+		assert.Equal(t, ErrDigitsCannotExceedLength, err)
+	})
+}
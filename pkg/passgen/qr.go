@@ -0,0 +1,383 @@
+package passgen
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+
+	"github.com/skip2/go-qrcode"
+)
+
+var (
+	ErrQrEmptyContent                       = errors.New("The content for QR generation should not be empty.")
+	ErrQrSizeMustBeGreaterThanZero          = errors.New("QR code image size must be greater than 0.")
+	ErrQrMarginMustBeEqualOrGreaterThanZero = errors.New("QR code margin must be greater than or equal to 0.")
+	ErrUnknownQrLevel                       = errors.New("QR code level must be one of low, medium, high or highest.")
+	ErrUnknownQrStyle                       = errors.New("QR code style must be one of ansi, inverted, utf8 or ascii.")
+)
+
+type QrLevel int
+
+const (
+	QrLevelLow QrLevel = iota
+	QrLevelMedium
+	QrLevelHigh
+	QrLevelHighest
+)
+
+var qrLevelNames = map[QrLevel]string{
+	QrLevelLow:     "low",
+	QrLevelMedium:  "medium",
+	QrLevelHigh:    "high",
+	QrLevelHighest: "highest",
+}
+
+func ParseQrLevel(name string) (QrLevel, error) {
+	for level, levelName := range qrLevelNames {
+		if strings.EqualFold(name, levelName) {
+			return level, nil
+		}
+	}
+
+	return 0, ErrUnknownQrLevel
+}
+
+func (l QrLevel) String() string {
+	return qrLevelNames[l]
+}
+
+func (l QrLevel) recoveryLevel() qrcode.RecoveryLevel {
+	switch l {
+	case QrLevelLow:
+		return qrcode.Low
+	case QrLevelMedium:
+		return qrcode.Medium
+	case QrLevelHigh:
+		return qrcode.High
+	default:
+		return qrcode.Highest
+	}
+}
+
+type QrStyle int
+
+const (
+	QrStyleAnsi QrStyle = iota
+	QrStyleInverted
+	QrStyleUtf8
+	QrStyleASCII
+)
+
+var qrStyleNames = map[QrStyle]string{
+	QrStyleAnsi:     "ansi",
+	QrStyleInverted: "inverted",
+	QrStyleUtf8:     "utf8",
+	QrStyleASCII:    "ascii",
+}
+
+func ParseQrStyle(name string) (QrStyle, error) {
+	for style, styleName := range qrStyleNames {
+		if strings.EqualFold(name, styleName) {
+			return style, nil
+		}
+	}
+
+	return 0, ErrUnknownQrStyle
+}
+
+func (s QrStyle) String() string {
+	return qrStyleNames[s]
+}
+
+type QrCode struct {
+	margin int
+	level  QrLevel
+	data   *qrcode.QRCode
+}
+
+func NewQrCode(content string, margin int) (*QrCode, error) {
+	return NewQrCodeWithLevel(content, margin, QrLevelHighest)
+}
+
+func NewQrCodeWithLevel(content string, margin int, level QrLevel) (*QrCode, error) {
+	if content == "" {
+		return nil, ErrQrEmptyContent
+	}
+
+	if margin < 0 {
+		return nil, ErrQrMarginMustBeEqualOrGreaterThanZero
+	}
+
+	if _, ok := qrLevelNames[level]; !ok {
+		return nil, ErrUnknownQrLevel
+	}
+
+	qr, err := qrcode.New(content, level.recoveryLevel())
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &QrCode{margin: margin, level: level, data: qr}, nil
+}
+
+// NewQrCodeFitting lowers the error correction level, starting at the given
+// one, until the rendered code fits into maxWidth terminal columns. The lowest
+// level is returned when nothing fits, and a maxWidth of 0 disables fitting.
+func NewQrCodeFitting(content string, margin int, level QrLevel, maxWidth int) (*QrCode, error) {
+	for {
+		qr, err := NewQrCodeWithLevel(content, margin, level)
+		if err != nil {
+			return nil, err
+		}
+
+		if maxWidth <= 0 || qr.Width() <= maxWidth || level <= QrLevelLow {
+			return qr, nil
+		}
+
+		level--
+	}
+}
+
+func (qr *QrCode) Level() QrLevel {
+	return qr.level
+}
+
+// Width returns the number of terminal columns used by the half block
+// renderers. GenerateASCII needs twice as many.
+func (qr *QrCode) Width() int {
+	return len(qr.data.Bitmap()) + qr.margin*2
+}
+
+// Render draws the QR code for a terminal or text file in the given style.
+func (qr *QrCode) Render(style QrStyle) string {
+	switch style {
+	case QrStyleInverted:
+		return qr.GenerateInvertedAnsiUtf8()
+	case QrStyleUtf8:
+		return qr.GenerateUtf8()
+	case QrStyleASCII:
+		return qr.GenerateASCII()
+	default:
+		return qr.GenerateAnsiUtf8()
+	}
+}
+
+func (qr *QrCode) GenerateAnsiUtf8() string {
+	white := "\033[40;37;1m"
+	reset := "\033[0m"
+
+	if runtime.GOOS == "windows" {
+		white, reset = "", ""
+	}
+
+	return qr.generateHalfBlocks(white, reset, false)
+}
+
+// GenerateInvertedAnsiUtf8 draws dark modules in black on a white background,
+// which scans better on terminals with a light color scheme.
+func (qr *QrCode) GenerateInvertedAnsiUtf8() string {
+	return qr.generateHalfBlocks("\033[47;30m", "\033[0m", true)
+}
+
+// GenerateUtf8 works like GenerateAnsiUtf8 without any escape sequences.
+func (qr *QrCode) GenerateUtf8() string {
+	return qr.generateHalfBlocks("", "", false)
+}
+
+// GenerateASCII draws every dark module as "##" and every light module as two
+// spaces, so the code survives being pasted into logs and plain text files.
+func (qr *QrCode) GenerateASCII() string {
+	var output strings.Builder
+
+	bitmap := qr.data.Bitmap()
+	realwidth := len(bitmap) + qr.margin*2
+	emptyRow := strings.Repeat("  ", realwidth) + "\n"
+	sideMargin := strings.Repeat("  ", qr.margin)
+
+	for range qr.margin {
+		output.WriteString(emptyRow)
+	}
+
+	for _, row := range bitmap {
+		output.WriteString(sideMargin)
+		for _, dark := range row {
+			if dark {
+				output.WriteString("##")
+			} else {
+				output.WriteString("  ")
+			}
+		}
+		output.WriteString(sideMargin)
+		output.WriteString("\n")
+	}
+
+	for range qr.margin {
+		output.WriteString(emptyRow)
+	}
+
+	return output.String()
+}
+
+// generateHalfBlocks packs two rows of modules into one line of half block
+// characters. By default light modules are drawn, inverted draws dark ones.
+func (qr *QrCode) generateHalfBlocks(color, reset string, inverted bool) string {
+
+	var output strings.Builder
+
+	empty := " "
+	lowhalf := "\342\226\204"
+	uphalf := "\342\226\200"
+	full := "\342\226\210"
+
+	if inverted {
+		empty, full = full, empty
+		lowhalf, uphalf = uphalf, lowhalf
+	}
+
+	bitmap := qr.data.Bitmap()
+	qrWidth := len(bitmap)
+
+	realwidth := qrWidth + qr.margin*2
+
+	// Top margin
+	qr.writeUTF8Margin(&output, realwidth, color, reset, full)
+
+	// Data rows - process two rows at a time for half-block characters
+	for y := 0; y < qrWidth; y += 2 {
+		output.WriteString(color)
+
+		// Left margin
+		for x := 0; x < qr.margin; x++ {
+			output.WriteString(full)
+		}
+
+		// QR data
+		for x := range qrWidth {
+			// Get current row
+			row1Dark := bitmap[y][x]
+			var row2Dark bool
+
+			// Get next row if it exists
+			if y < qrWidth-1 {
+				row2Dark = bitmap[y+1][x]
+			}
+
+			// Determine which character to output based on the two pixels
+			if row1Dark {
+				if y < qrWidth-1 && row2Dark {
+					output.WriteString(empty) // Both dark
+				} else {
+					output.WriteString(lowhalf) // Top dark, bottom light
+				}
+			} else if y < qrWidth-1 && row2Dark {
+				output.WriteString(uphalf) // Top light, bottom dark
+			} else {
+				output.WriteString(full) // Both light (or bottom row)
+			}
+		}
+
+		// Right margin
+		for x := 0; x < qr.margin; x++ {
+			output.WriteString(full)
+		}
+
+		output.WriteString(reset)
+		output.WriteString("\n")
+	}
+
+	// Bottom margin
+	qr.writeUTF8Margin(&output, realwidth, color, reset, full)
+
+	return output.String()
+}
+
+func (qr *QrCode) writeUTF8Margin(qrOutput *strings.Builder, realwidth int, white, reset, full string) {
+	for y := 0; y < qr.margin/2; y++ {
+		qrOutput.WriteString(white)
+		for range realwidth {
+			qrOutput.WriteString(full)
+		}
+		qrOutput.WriteString(reset)
+		qrOutput.WriteString("\n")
+	}
+}
+
+func (qr *QrCode) PNG(size int) ([]byte, error) {
+	if size <= 0 {
+		return nil, ErrQrSizeMustBeGreaterThanZero
+	}
+
+	return qr.data.PNG(size)
+}
+
+func (qr *QrCode) SVG(size int) (string, error) {
+	if size <= 0 {
+		return "", ErrQrSizeMustBeGreaterThanZero
+	}
+
+	bitmap := qr.data.Bitmap()
+	qrWidth := len(bitmap)
+
+	var output strings.Builder
+
+	fmt.Fprintf(&output, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`, size, size, qrWidth, qrWidth)
+	output.WriteString("\n")
+	output.WriteString(`<rect width="100%" height="100%" fill="#ffffff"/>`)
+	output.WriteString("\n")
+	output.WriteString(`<path fill="#000000" d="`)
+
+	for y, row := range bitmap {
+		for x, dark := range row {
+			if dark {
+				fmt.Fprintf(&output, "M%d %dh1v1h-1z", x, y)
+			}
+		}
+	}
+
+	output.WriteString(`"/>`)
+	output.WriteString("\n</svg>\n")
+
+	return output.String(), nil
+}
+
+func (qr *QrCode) WritePNG(path string, size int) error {
+	data, err := qr.PNG(size)
+	if err != nil {
+		return err
+	}
+
+	return writeSecretFile(path, data)
+}
+
+func (qr *QrCode) WriteSVG(path string, size int) error {
+	data, err := qr.SVG(size)
+	if err != nil {
+		return err
+	}
+
+	return writeSecretFile(path, []byte(data))
+}
+
+// writeSecretFile writes data readable only by the current user, tightening
+// the permissions of an already existing file as well.
+func writeSecretFile(path string, data []byte) error {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+
+	if err := file.Chmod(0600); err != nil {
+		file.Close()
+		return err
+	}
+
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+
+	return file.Close()
+}
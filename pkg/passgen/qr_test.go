@@ -0,0 +1,524 @@
+package passgen
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewQrCode(t *testing.T) {
+	t.Run("should create QR code with valid content", func(t *testing.T) {
+		content := "Hello, World!"
+		margin := 2
+
+		qr, err := NewQrCode(content, margin)
+
+		require.NoError(t, err)
+		assert.NotNil(t, qr)
+		assert.Equal(t, margin, qr.margin)
+		assert.NotNil(t, qr.data)
+	})
+
+	t.Run("should return error for empty content", func(t *testing.T) {
+		margin := 2
+
+		qr, err := NewQrCode("", margin)
+
+		assert.Error(t, err)
+		assert.Equal(t, ErrQrEmptyContent, err)
+		assert.Nil(t, qr)
+	})
+
+	t.Run("should create QR code with zero margin", func(t *testing.T) {
+		content := "Test"
+		margin := 0
+
+		qr, err := NewQrCode(content, margin)
+
+		require.NoError(t, err)
+		assert.NotNil(t, qr)
+		assert.Equal(t, margin, qr.margin)
+	})
+
+	t.Run("should create QR code with large margin", func(t *testing.T) {
+		content := "Test"
+		margin := 10
+
+		qr, err := NewQrCode(content, margin)
+
+		require.NoError(t, err)
+		assert.NotNil(t, qr)
+		assert.Equal(t, margin, qr.margin)
+	})
+
+	t.Run("should handle special characters in content", func(t *testing.T) {
+		content := "Test with special chars: !@#$%^&*()"
+		margin := 2
+
+		qr, err := NewQrCode(content, margin)
+
+		require.NoError(t, err)
+		assert.NotNil(t, qr)
+		assert.Equal(t, margin, qr.margin)
+	})
+
+	t.Run("should handle unicode content", func(t *testing.T) {
+		content := "Unicode test: 你好世界"
+		margin := 2
+
+		qr, err := NewQrCode(content, margin)
+
+		require.NoError(t, err)
+		assert.NotNil(t, qr)
+		assert.Equal(t, margin, qr.margin)
+	})
+}
+
+func TestParseQrLevel(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected QrLevel
+	}{
+		{"low", QrLevelLow},
+		{"medium", QrLevelMedium},
+		{"high", QrLevelHigh},
+		{"highest", QrLevelHighest},
+		{"HIGH", QrLevelHigh},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			level, err := ParseQrLevel(tt.name)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, level)
+		})
+	}
+
+	t.Run("unknown level", func(t *testing.T) {
+		_, err := ParseQrLevel("maximum")
+
+		assert.Equal(t, ErrUnknownQrLevel, err)
+	})
+}
+
+func TestNewQrCodeWithLevel(t *testing.T) {
+	t.Run("lower levels produce smaller codes", func(t *testing.T) {
+		content := strings.Repeat("x", 40)
+
+		low, err := NewQrCodeWithLevel(content, 1, QrLevelLow)
+		require.NoError(t, err)
+		highest, err := NewQrCodeWithLevel(content, 1, QrLevelHighest)
+		require.NoError(t, err)
+
+		assert.Equal(t, QrLevelLow, low.Level())
+		assert.Less(t, low.Width(), highest.Width())
+	})
+
+	t.Run("width includes margin", func(t *testing.T) {
+		qr, err := NewQrCodeWithLevel("Test", 3, QrLevelMedium)
+
+		require.NoError(t, err)
+		assert.Equal(t, len(qr.data.Bitmap())+6, qr.Width())
+	})
+
+	t.Run("negative margin", func(t *testing.T) {
+		qr, err := NewQrCodeWithLevel("Test", -1, QrLevelMedium)
+
+		assert.Equal(t, ErrQrMarginMustBeEqualOrGreaterThanZero, err)
+		assert.Nil(t, qr)
+	})
+
+	t.Run("unknown level", func(t *testing.T) {
+		qr, err := NewQrCodeWithLevel("Test", 1, QrLevel(-1))
+
+		assert.Equal(t, ErrUnknownQrLevel, err)
+		assert.Nil(t, qr)
+	})
+}
+
+func TestNewQrCodeFitting(t *testing.T) {
+	content := strings.Repeat("x", 40)
+	low, err := NewQrCodeWithLevel(content, 1, QrLevelLow)
+	require.NoError(t, err)
+
+	t.Run("keeps level when there is no limit", func(t *testing.T) {
+		qr, err := NewQrCodeFitting(content, 1, QrLevelHighest, 0)
+
+		require.NoError(t, err)
+		assert.Equal(t, QrLevelHighest, qr.Level())
+	})
+
+	t.Run("falls back to a level that fits", func(t *testing.T) {
+		qr, err := NewQrCodeFitting(content, 1, QrLevelHighest, low.Width())
+
+		require.NoError(t, err)
+		assert.LessOrEqual(t, qr.Width(), low.Width())
+	})
+
+	t.Run("uses the lowest level when nothing fits", func(t *testing.T) {
+		qr, err := NewQrCodeFitting(content, 1, QrLevelHighest, 1)
+
+		require.NoError(t, err)
+		assert.Equal(t, QrLevelLow, qr.Level())
+	})
+
+	t.Run("unknown level", func(t *testing.T) {
+		qr, err := NewQrCodeFitting(content, 1, QrLevel(-1), 1)
+
+		assert.Equal(t, ErrUnknownQrLevel, err)
+		assert.Nil(t, qr)
+	})
+}
+
+func TestParseQrStyle(t *testing.T) {
+	for style, name := range qrStyleNames {
+		t.Run(name, func(t *testing.T) {
+			parsed, err := ParseQrStyle(name)
+
+			require.NoError(t, err)
+			assert.Equal(t, style, parsed)
+			assert.Equal(t, name, parsed.String())
+		})
+	}
+
+	t.Run("unknown style", func(t *testing.T) {
+		_, err := ParseQrStyle("color")
+
+		assert.Equal(t, ErrUnknownQrStyle, err)
+	})
+}
+
+func TestQrCodeAlternativeRenderers(t *testing.T) {
+	qr, err := NewQrCode("Render", 2)
+	require.NoError(t, err)
+	qrWidth := len(qr.data.Bitmap())
+
+	t.Run("inverted", func(t *testing.T) {
+		output := qr.GenerateInvertedAnsiUtf8()
+
+		assert.Contains(t, output, "\033[47;30m")
+		assert.Equal(t, output, qr.Render(QrStyleInverted))
+
+		// The margin is light, so it is not drawn at all.
+		firstLine := strings.SplitN(output, "\n", 2)[0]
+		assert.Equal(t, "\033[47;30m"+strings.Repeat(" ", qr.Width())+"\033[0m", firstLine)
+	})
+
+	t.Run("plain UTF-8", func(t *testing.T) {
+		output := qr.GenerateUtf8()
+
+		assert.NotContains(t, output, "\033")
+		assert.Contains(t, output, "\342\226\210")
+		assert.Equal(t, output, qr.Render(QrStyleUtf8))
+	})
+
+	t.Run("ASCII", func(t *testing.T) {
+		output := qr.GenerateASCII()
+		lines := strings.Split(strings.TrimSuffix(output, "\n"), "\n")
+
+		assert.Len(t, lines, qrWidth+4)
+		for _, line := range lines {
+			assert.Len(t, line, qr.Width()*2)
+			assert.Empty(t, strings.Trim(line, "# "))
+		}
+		// go-qrcode adds a four module quiet zone of its own inside the margin.
+		assert.Equal(t, strings.Repeat(" ", 12)+strings.Repeat("#", 14), lines[6][:26])
+		assert.Equal(t, output, qr.Render(QrStyleASCII))
+	})
+
+	t.Run("default style", func(t *testing.T) {
+		assert.Equal(t, qr.GenerateAnsiUtf8(), qr.Render(QrStyleAnsi))
+	})
+}
+
+func TestQrCodeGenerateAnsiUtf8(t *testing.T) {
+	t.Run("should generate UTF-8 output for simple content", func(t *testing.T) {
+		qr, err := NewQrCode("Test", 2)
+		require.NoError(t, err)
+
+		output := qr.GenerateAnsiUtf8()
+
+		assert.NotEmpty(t, output)
+		assert.Contains(t, output, "\033[40;37;1m")
+		assert.Contains(t, output, "\033[0m")
+		assert.Contains(t, output, "\n")
+	})
+
+	t.Run("should generate output with zero margin", func(t *testing.T) {
+		qr, err := NewQrCode("Test", 0)
+		require.NoError(t, err)
+
+		output := qr.GenerateAnsiUtf8()
+
+		assert.NotEmpty(t, output)
+		assert.Contains(t, output, "\033[40;37;1m")
+		assert.Contains(t, output, "\033[0m")
+	})
+
+	t.Run("should generate output with large margin", func(t *testing.T) {
+		qr, err := NewQrCode("A", 8)
+		require.NoError(t, err)
+
+		output := qr.GenerateAnsiUtf8()
+
+		assert.NotEmpty(t, output)
+		lines := strings.Split(strings.TrimRight(output, "\n"), "\n")
+		assert.Greater(t, len(lines), 10)
+	})
+
+	t.Run("should contain expected UTF-8 block characters", func(t *testing.T) {
+		qr, err := NewQrCode("Hello", 1)
+		require.NoError(t, err)
+
+		output := qr.GenerateAnsiUtf8()
+
+		assert.Condition(t, func() (success bool) {
+			success = strings.Contains(output, "\342\226\204") ||
+				strings.Contains(output, "\342\226\200") ||
+				strings.Contains(output, "\342\226\210") ||
+				strings.Contains(output, " ")
+			return
+		})
+	})
+
+	t.Run("should generate consistent output for same input", func(t *testing.T) {
+		qr, err := NewQrCode("Consistent", 2)
+		require.NoError(t, err)
+
+		output1 := qr.GenerateAnsiUtf8()
+		output2 := qr.GenerateAnsiUtf8()
+
+		assert.Equal(t, output1, output2, "Multiple calls should produce identical output")
+	})
+
+	t.Run("should handle odd QR code dimensions", func(t *testing.T) {
+		qr, err := NewQrCode("A", 1)
+		require.NoError(t, err)
+
+		output := qr.GenerateAnsiUtf8()
+
+		assert.NotEmpty(t, output)
+		lines := strings.Split(strings.TrimRight(output, "\n"), "\n")
+		assert.NotEmpty(t, lines)
+	})
+
+	t.Run("should properly format lines with color codes", func(t *testing.T) {
+		qr, err := NewQrCode("Format", 1)
+		require.NoError(t, err)
+
+		output := qr.GenerateAnsiUtf8()
+
+		for line := range strings.SplitSeq(output, "\n") {
+			if len(line) > 0 {
+				assert.Contains(t, line, "\033[40;37;1m", "Each content line should start with white color code")
+				assert.Contains(t, line, "\033[0m", "Each content line should end with reset code")
+			}
+		}
+	})
+}
+
+func TestQrCode_writeUTF8Margin(t *testing.T) {
+	t.Run("should write margin correctly", func(t *testing.T) {
+		qr, err := NewQrCode("Test", 4)
+		require.NoError(t, err)
+
+		var output strings.Builder
+		white := "\033[40;37;1m"
+		reset := "\033[0m"
+		full := "\342\226\210"
+		realwidth := 10
+
+		qr.writeUTF8Margin(&output, realwidth, white, reset, full)
+
+		result := output.String()
+		assert.NotEmpty(t, result)
+		assert.Contains(t, result, white)
+		assert.Contains(t, result, reset)
+		assert.Contains(t, result, full)
+
+		lines := strings.Split(strings.TrimRight(result, "\n"), "\n")
+		assert.Len(t, lines, 2)
+	})
+
+	t.Run("should handle zero margin", func(t *testing.T) {
+		qr, err := NewQrCode("Test", 0)
+		require.NoError(t, err)
+
+		var output strings.Builder
+		white := "\033[40;37;1m"
+		reset := "\033[0m"
+		full := "\342\226\210"
+		realwidth := 10
+
+		qr.writeUTF8Margin(&output, realwidth, white, reset, full)
+
+		result := output.String()
+		assert.Empty(t, result)
+	})
+
+	t.Run("should handle odd margin", func(t *testing.T) {
+		qr, err := NewQrCode("Test", 3)
+		require.NoError(t, err)
+
+		var output strings.Builder
+		white := "\033[40;37;1m"
+		reset := "\033[0m"
+		full := "\342\226\210"
+		realwidth := 10
+
+		qr.writeUTF8Margin(&output, realwidth, white, reset, full)
+
+		result := output.String()
+		lines := strings.Split(strings.TrimRight(result, "\n"), "\n")
+		assert.Len(t, lines, 1)
+	})
+}
+
+func TestQrCodePNG(t *testing.T) {
+	t.Run("should encode PNG image", func(t *testing.T) {
+		qr, err := NewQrCode("Test", 1)
+		require.NoError(t, err)
+
+		data, err := qr.PNG(128)
+
+		require.NoError(t, err)
+		assert.True(t, bytes.HasPrefix(data, []byte("\x89PNG\r\n\x1a\n")))
+	})
+
+	t.Run("should return error for invalid size", func(t *testing.T) {
+		qr, err := NewQrCode("Test", 1)
+		require.NoError(t, err)
+
+		data, err := qr.PNG(0)
+
+		assert.Nil(t, data)
+		assert.Equal(t, ErrQrSizeMustBeGreaterThanZero, err)
+	})
+}
+
+func TestQrCodeSVG(t *testing.T) {
+	t.Run("should render dark modules as path", func(t *testing.T) {
+		qr, err := NewQrCode("Test", 1)
+		require.NoError(t, err)
+		width := len(qr.data.Bitmap())
+
+		svg, err := qr.SVG(200)
+
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(svg, "<svg "))
+		assert.Contains(t, svg, `width="200" height="200"`)
+		assert.Contains(t, svg, fmt.Sprintf(`viewBox="0 0 %d %d"`, width, width))
+		assert.Contains(t, svg, "h1v1h-1z")
+		assert.True(t, strings.HasSuffix(svg, "</svg>\n"))
+	})
+
+	t.Run("should return error for invalid size", func(t *testing.T) {
+		qr, err := NewQrCode("Test", 1)
+		require.NoError(t, err)
+
+		svg, err := qr.SVG(-1)
+
+		assert.Empty(t, svg)
+		assert.Equal(t, ErrQrSizeMustBeGreaterThanZero, err)
+	})
+}
+
+func TestQrCodeWriteFiles(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("file permissions are not supported on windows")
+	}
+
+	qr, err := NewQrCode("Secret", 1)
+	require.NoError(t, err)
+
+	t.Run("should write PNG with private permissions", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "qr.png")
+
+		err := qr.WritePNG(path, 64)
+
+		require.NoError(t, err)
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	})
+
+	t.Run("should tighten permissions of existing SVG", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "qr.svg")
+		require.NoError(t, os.WriteFile(path, []byte("old content that is longer than nothing"), 0644))
+
+		err := qr.WriteSVG(path, 64)
+
+		require.NoError(t, err)
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(string(data), "<svg "))
+	})
+}
+
+func TestErrQrEmptyContent(t *testing.T) {
+	t.Run("should have correct error message", func(t *testing.T) {
+		expectedMessage := "The content for QR generation should not be empty."
+		assert.Equal(t, expectedMessage, ErrQrEmptyContent.Error())
+	})
+}
+
+func TestQrCodeIntegration(t *testing.T) {
+	t.Run("should create and generate QR code successfully", func(t *testing.T) {
+		content := "Integration Test"
+		margin := 3
+
+		qr, err := NewQrCode(content, margin)
+		require.NoError(t, err)
+		require.NotNil(t, qr)
+
+		output := qr.GenerateAnsiUtf8()
+		assert.NotEmpty(t, output)
+
+		lines := strings.Split(strings.TrimRight(output, "\n"), "\n")
+		assert.Greater(t, len(lines), margin/2, "Should have at least margin lines")
+
+		for i, line := range lines {
+			if len(line) > 0 {
+				assert.Contains(t, line, "\033[40;37;1m", "Line %d should contain white color code", i)
+				assert.Contains(t, line, "\033[0m", "Line %d should contain reset code", i)
+			}
+		}
+	})
+}
+
+func BenchmarkNewQrCode(b *testing.B) {
+	content := "Benchmark Test Content"
+	margin := 2
+
+	for b.Loop() {
+		qr, err := NewQrCode(content, margin)
+		if err != nil {
+			b.Fatal(err)
+		}
+		_ = qr
+	}
+}
+
+func BenchmarkGenerateAnsiUtf8(b *testing.B) {
+	qr, err := NewQrCode("Benchmark Test", 2)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	for b.Loop() {
+		output := qr.GenerateAnsiUtf8()
+		_ = output
+	}
+}
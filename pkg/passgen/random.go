@@ -0,0 +1,55 @@
+package passgen
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"io"
+	mathrand "math/rand/v2"
+)
+
+// RandomSource supplies the randomness of every generator. Options without a
+// source use CryptoRandom.
+type RandomSource interface {
+	io.Reader
+
+	// Secure reports whether the source is fit for real secrets.
+	Secure() bool
+}
+
+var CryptoRandom RandomSource = cryptoRandomSource{}
+
+type cryptoRandomSource struct{}
+
+func (cryptoRandomSource) Read(p []byte) (int, error) {
+	return rand.Read(p)
+}
+
+func (cryptoRandomSource) Secure() bool {
+	return true
+}
+
+type seededRandomSource struct {
+	chacha *mathrand.ChaCha8
+}
+
+// NewSeededRandomSource returns a deterministic source for tests and debugging:
+// the same seed always produces the same output. Never use it for secrets.
+func NewSeededRandomSource(seed string) RandomSource {
+	return &seededRandomSource{chacha: mathrand.NewChaCha8(sha256.Sum256([]byte(seed)))}
+}
+
+func (s *seededRandomSource) Read(p []byte) (int, error) {
+	return s.chacha.Read(p)
+}
+
+func (s *seededRandomSource) Secure() bool {
+	return false
+}
+
+func randomSourceOrDefault(source RandomSource) RandomSource {
+	if source == nil {
+		return CryptoRandom
+	}
+
+	return source
+}
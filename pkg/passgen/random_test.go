@@ -0,0 +1,94 @@
+package passgen
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRandomSource(t *testing.T) {
+	t.Run("crypto source is secure", func(t *testing.T) {
+		assert.True(t, CryptoRandom.Secure())
+		assert.Equal(t, CryptoRandom, randomSourceOrDefault(nil))
+	})
+
+	t.Run("seeded source is deterministic", func(t *testing.T) {
+		first, second := make([]byte, 32), make([]byte, 32)
+
+		_, err := NewSeededRandomSource("seed").Read(first)
+		require.NoError(t, err)
+		_, err = NewSeededRandomSource("seed").Read(second)
+		require.NoError(t, err)
+
+		assert.Equal(t, first, second)
+		assert.False(t, NewSeededRandomSource("seed").Secure())
+	})
+
+	t.Run("different seeds differ", func(t *testing.T) {
+		first, second := make([]byte, 32), make([]byte, 32)
+
+		NewSeededRandomSource("one").Read(first)
+		NewSeededRandomSource("two").Read(second)
+
+		assert.NotEqual(t, first, second)
+	})
+}
+
+func TestSeededGenerators(t *testing.T) {
+	t.Run("passwords", func(t *testing.T) {
+		options := PasswordGeneratorOptions{
+			Length:       16,
+			Lowercase:    true,
+			Uppercase:    true,
+			Numbers:      true,
+			Symbols:      true,
+			AvoidRepeats: 1,
+			MinSymbols:   2,
+			Random:       NewSeededRandomSource("passgen"),
+		}
+
+		passwords, err := GeneratePasswords(options, 2)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"ix;a+a;tv?l)Hij[", "(89`7/mBm]Eu\\Rl5"}, passwords)
+	})
+
+	t.Run("passphrase", func(t *testing.T) {
+		options := PassphraseGeneratorOptions{
+			Words:     4,
+			Separator: "-",
+			Number:    true,
+			Random:    NewSeededRandomSource("passgen"),
+		}
+
+		passphrase, err := GeneratePassphrase(options)
+
+		require.NoError(t, err)
+		assert.Equal(t, "deniable-chewing-rockstar-sprung3", passphrase)
+	})
+
+	t.Run("pronounceable", func(t *testing.T) {
+		options := PronounceableGeneratorOptions{
+			Length:     10,
+			Capitalize: true,
+			Digits:     2,
+			Random:     NewSeededRandomSource("passgen"),
+		}
+
+		password, err := GeneratePronounceable(options)
+
+		require.NoError(t, err)
+		assert.Equal(t, "07faSotuni", password)
+	})
+
+	t.Run("TOTP secret", func(t *testing.T) {
+		options := NewTotpGeneratorOptions()
+		options.Random = NewSeededRandomSource("passgen")
+
+		secret, err := GenerateTotpSecret(*options)
+
+		require.NoError(t, err)
+		assert.Equal(t, "A2NYH3YVPV4F6VYNEP6S5TCKDKJLBQCH", secret)
+	})
+}
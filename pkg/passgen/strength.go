@@ -0,0 +1,226 @@
+package passgen
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	minPatternLength      = 3
+	minKeyboardWalkLength = 4
+	recommendedLength     = 12
+	// otherCharsPoolSize is assumed for characters outside of the known
+	// character sets, e.g. letters of other alphabets or emoji.
+	otherCharsPoolSize = 100
+)
+
+var keyboardRows = []string{
+	"`1234567890-=",
+	"qwertyuiop[]\\",
+	"asdfghjkl;'",
+	"zxcvbnm,./",
+}
+
+var strengthRatings = []string{"very weak", "weak", "fair", "strong", "very strong"}
+
+// StrengthReport is the result of checking an existing password. Score goes
+// from 0 (very weak) to 4 (very strong).
+type StrengthReport struct {
+	Length    int      `json:"length"`
+	Classes   []string `json:"classes"`
+	Entropy   float64  `json:"entropy"`
+	CrackTime string   `json:"crack_time"`
+	Score     int      `json:"score"`
+	Rating    string   `json:"rating"`
+	Reasons   []string `json:"reasons"`
+}
+
+// CheckPassword estimates the strength of a password chosen by a person.
+// Characters that continue a repeat, a sequence like "abc" or a keyboard walk
+// like "qwer" are assumed to add no entropy.
+func CheckPassword(password string) StrengthReport {
+	characters := []rune(password)
+	classes, poolSize := detectCharacterClasses(characters)
+
+	predictable := make([]bool, len(characters))
+	reasons := []string{}
+
+	if len(characters) < recommendedLength {
+		reasons = append(reasons, fmt.Sprintf("Shorter than the recommended %d characters.", recommendedLength))
+	}
+
+	if len(classes) <= 1 {
+		reasons = append(reasons, "Uses a single character class.")
+	} else if len(classes) == 2 {
+		reasons = append(reasons, "Uses only 2 character classes.")
+	}
+
+	for _, run := range findRuns(characters, isRepeat, minPatternLength) {
+		reasons = append(reasons, fmt.Sprintf("Contains the repeated characters %q.", string(characters[run[0]:run[1]])))
+		markPredictable(predictable, run)
+	}
+
+	for _, run := range findSequences(characters, minPatternLength) {
+		reasons = append(reasons, fmt.Sprintf("Contains the sequence %q.", string(characters[run[0]:run[1]])))
+		markPredictable(predictable, run)
+	}
+
+	for _, run := range findKeyboardWalks(characters) {
+		reasons = append(reasons, fmt.Sprintf("Contains the keyboard walk %q.", string(characters[run[0]:run[1]])))
+		markPredictable(predictable, run)
+	}
+
+	effectiveLength := 0
+	for _, skip := range predictable {
+		if !skip {
+			effectiveLength++
+		}
+	}
+
+	bits := 0.0
+	if poolSize > 0 {
+		bits = float64(effectiveLength) * math.Log2(float64(poolSize))
+	}
+	entropy := NewEntropy(bits)
+	score := strengthScore(bits)
+
+	return StrengthReport{
+		Length:    utf8.RuneCountInString(password),
+		Classes:   classes,
+		Entropy:   math.Round(bits*100) / 100,
+		CrackTime: entropy.CrackTime(),
+		Score:     score,
+		Rating:    strengthRatings[score],
+		Reasons:   reasons,
+	}
+}
+
+func detectCharacterClasses(characters []rune) ([]string, int) {
+	charsets := []struct {
+		name       string
+		characters string
+	}{
+		{"uppercase", UppercaseChars},
+		{"lowercase", LowercaseChars},
+		{"numbers", NumberChars},
+		{"symbols", SymbolChars},
+	}
+
+	classes := []string{}
+	poolSize := 0
+	known := ""
+
+	for _, charset := range charsets {
+		known += charset.characters
+		if strings.ContainsAny(string(characters), charset.characters) {
+			classes = append(classes, charset.name)
+			poolSize += len(charset.characters)
+		}
+	}
+
+	for _, character := range characters {
+		if !strings.ContainsRune(known, character) {
+			classes = append(classes, "other")
+			poolSize += otherCharsPoolSize
+			break
+		}
+	}
+
+	return classes, poolSize
+}
+
+func strengthScore(bits float64) int {
+	switch {
+	case bits < 28:
+		return 0
+	case bits < 36:
+		return 1
+	case bits < 60:
+		return 2
+	case bits < 80:
+		return 3
+	default:
+		return 4
+	}
+}
+
+// findRuns returns the [start, end) ranges of at least minLength characters
+// where every character continues the previous one.
+func findRuns(characters []rune, continues func(previous, current rune) bool, minLength int) [][2]int {
+	var runs [][2]int
+
+	start := 0
+	for i := 1; i <= len(characters); i++ {
+		if i < len(characters) && continues(characters[i-1], characters[i]) {
+			continue
+		}
+
+		if i-start >= minLength {
+			runs = append(runs, [2]int{start, i})
+		}
+		start = i
+	}
+
+	return runs
+}
+
+func isRepeat(previous, current rune) bool {
+	return previous == current
+}
+
+// findSequences finds ascending and descending runs of letters or digits,
+// like "abc", "321" or "XYZ".
+func findSequences(characters []rune, minLength int) [][2]int {
+	step := func(direction rune) func(previous, current rune) bool {
+		return func(previous, current rune) bool {
+			previous, current = unicode.ToLower(previous), unicode.ToLower(current)
+			if !sameSequenceClass(previous, current) {
+				return false
+			}
+
+			return current-previous == direction
+		}
+	}
+
+	return append(findRuns(characters, step(1), minLength), findRuns(characters, step(-1), minLength)...)
+}
+
+// sameSequenceClass reports whether both characters are lowercase letters,
+// uppercase letters or digits, so "`abc" or "9:;" do not join a sequence.
+func sameSequenceClass(previous, current rune) bool {
+	return (unicode.IsLower(previous) && unicode.IsLower(current)) ||
+		(unicode.IsUpper(previous) && unicode.IsUpper(current)) ||
+		(unicode.IsDigit(previous) && unicode.IsDigit(current))
+}
+
+func findKeyboardWalks(characters []rune) [][2]int {
+	lower := []rune(strings.ToLower(string(characters)))
+	adjacent := func(previous, current rune) bool {
+		for _, row := range keyboardRows {
+			if strings.Contains(row, string([]rune{previous, current})) ||
+				strings.Contains(row, string([]rune{current, previous})) {
+				return true
+			}
+		}
+		return false
+	}
+
+	var walks [][2]int
+	for _, run := range findRuns(lower, adjacent, minKeyboardWalkLength) {
+		// Plain sequences like "1234" are already reported as sequences.
+		if len(findSequences(lower[run[0]:run[1]], run[1]-run[0])) == 0 {
+			walks = append(walks, run)
+		}
+	}
+
+	return walks
+}
+
+func markPredictable(predictable []bool, run [2]int) {
+	for i := run[0] + 1; i < run[1]; i++ {
+		predictable[i] = true
+	}
+}
@@ -0,0 +1,94 @@
+package passgen
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckPassword(t *testing.T) {
+	tests := []struct {
+		name     string
+		password string
+		classes  []string
+		score    int
+		reasons  []string
+	}{
+		{
+			name:     "short lowercase",
+			password: "secret",
+			classes:  []string{"lowercase"},
+			score:    1,
+			reasons:  []string{"Shorter than the recommended 12 characters.", "Uses a single character class."},
+		},
+		{
+			name:     "repeats and sequences",
+			password: "aaaa1234Xyz!",
+			classes:  []string{"uppercase", "lowercase", "numbers", "symbols"},
+			score:    0,
+			reasons:  []string{`Contains the repeated characters "aaaa".`, `Contains the sequence "1234".`, `Contains the sequence "Xyz".`},
+		},
+		{
+			name:     "descending sequence",
+			password: "Zq9!cba87Wm#",
+			classes:  []string{"uppercase", "lowercase", "numbers", "symbols"},
+			score:    3,
+			reasons:  []string{`Contains the sequence "cba".`},
+		},
+		{
+			name:     "symbol to letter or digit boundaries",
+			password: "Wq`ab9:;@ABk",
+			classes:  []string{"uppercase", "lowercase", "numbers", "symbols"},
+			score:    3,
+			reasons:  []string{},
+		},
+		{
+			name:     "sequence after a symbol",
+			password: "Wq`abc9:;@ABk",
+			classes:  []string{"uppercase", "lowercase", "numbers", "symbols"},
+			score:    3,
+			reasons:  []string{`Contains the sequence "abc".`},
+		},
+		{
+			name:     "keyboard walk",
+			password: "Qwerty!Asdf9",
+			classes:  []string{"uppercase", "lowercase", "numbers", "symbols"},
+			score:    0,
+			reasons:  []string{`Contains the keyboard walk "Qwerty".`, `Contains the keyboard walk "Asdf".`},
+		},
+		{
+			name:     "strong random password",
+			password: "h7$Kq2!vN9@xLp4&",
+			classes:  []string{"uppercase", "lowercase", "numbers", "symbols"},
+			score:    4,
+			reasons:  []string{},
+		},
+		{
+			name:     "other characters",
+			password: "лорем ипсум долор",
+			classes:  []string{"other"},
+			score:    4,
+			reasons:  []string{"Uses a single character class."},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := CheckPassword(tt.password)
+
+			assert.Equal(t, tt.classes, report.Classes)
+			assert.Equal(t, tt.score, report.Score, "entropy %.2f", report.Entropy)
+			assert.Equal(t, strengthRatings[tt.score], report.Rating)
+			assert.Equal(t, tt.reasons, report.Reasons)
+		})
+	}
+}
+
+func TestCheckPasswordPatternsLowerEntropy(t *testing.T) {
+	random := CheckPassword("mK4!pZ8@")
+	walk := CheckPassword("qwer1234")
+
+	assert.Equal(t, 8, walk.Length)
+	assert.Less(t, walk.Entropy, random.Entropy)
+	assert.Equal(t, "less than a second", walk.CrackTime)
+}
@@ -0,0 +1,195 @@
+package passgen
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"math"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidTotpSecret = errors.New("TOTP secret is not valid Base32.")
+	ErrInvalidTotpURI    = errors.New("TOTP URI must look like otpauth://totp/label?secret=...")
+)
+
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateTotpSecret returns a random Base32 encoded secret for time-based
+// one-time passwords (RFC 6238).
+func GenerateTotpSecret(options TotpGeneratorOptions) (string, error) {
+	if _, err := options.Validate(); err != nil {
+		return "", err
+	}
+
+	secret := make([]byte, options.Bytes)
+	if _, err := io.ReadFull(randomSourceOrDefault(options.Random), secret); err != nil {
+		return "", err
+	}
+
+	return totpEncoding.EncodeToString(secret), nil
+}
+
+// TotpURI returns the otpauth:// key URI understood by authenticator apps.
+// The label is the account, prefixed with the issuer if there is one.
+func TotpURI(secret string, options TotpGeneratorOptions) string {
+	label := escapeTotpLabel(options.Account)
+	if options.Issuer != "" {
+		label = escapeTotpLabel(options.Issuer) + ":" + label
+	}
+
+	query := url.Values{}
+	query.Set("secret", secret)
+	if options.Issuer != "" {
+		query.Set("issuer", options.Issuer)
+	}
+	query.Set("algorithm", options.Algorithm)
+	query.Set("digits", strconv.Itoa(options.Digits))
+	query.Set("period", strconv.Itoa(options.Period))
+
+	// Key URIs use percent-encoding, some apps show a form-encoded + as is.
+	// Literal plus signs are already encoded as %2B.
+	return "otpauth://totp/" + label + "?" + strings.ReplaceAll(query.Encode(), "+", "%20")
+}
+
+// ParseTotpURI extracts the secret and the options from an otpauth:// URI.
+// Missing parameters fall back to the defaults of authenticator apps.
+func ParseTotpURI(uri string) (string, *TotpGeneratorOptions, error) {
+	return ParseTotpURIWithOptions(uri, *NewTotpGeneratorOptions())
+}
+
+// ParseTotpURIWithOptions is like ParseTotpURI, but parameters missing from
+// the URI keep their value from base instead of the defaults.
+func ParseTotpURIWithOptions(uri string, base TotpGeneratorOptions) (string, *TotpGeneratorOptions, error) {
+	parsed, err := url.Parse(uri)
+	if err != nil || parsed.Scheme != "otpauth" || parsed.Host != "totp" {
+		return "", nil, ErrInvalidTotpURI
+	}
+
+	query := parsed.Query()
+	secret := query.Get("secret")
+	if secret == "" {
+		return "", nil, ErrInvalidTotpURI
+	}
+
+	decoded, err := decodeTotpSecret(secret)
+	if err != nil {
+		return "", nil, err
+	}
+
+	options := &base
+	options.Bytes = len(decoded)
+	if issuer := query.Get("issuer"); issuer != "" {
+		options.Issuer = issuer
+	}
+
+	// Split the escaped label, colons inside the issuer or account are escaped.
+	label := strings.TrimPrefix(parsed.EscapedPath(), "/")
+	issuer, account, found := strings.Cut(label, ":")
+	if !found {
+		issuer, account = "", label
+	}
+
+	if account, err = url.PathUnescape(account); err != nil {
+		return "", nil, ErrInvalidTotpURI
+	}
+	if account = strings.TrimSpace(account); account != "" {
+		options.Account = account
+	}
+
+	if issuer, err = url.PathUnescape(issuer); err != nil {
+		return "", nil, ErrInvalidTotpURI
+	}
+	if query.Get("issuer") == "" && issuer != "" {
+		options.Issuer = issuer
+	}
+
+	if algorithm := query.Get("algorithm"); algorithm != "" {
+		options.Algorithm = strings.ToUpper(algorithm)
+	}
+
+	if digits := query.Get("digits"); digits != "" {
+		if options.Digits, err = strconv.Atoi(digits); err != nil {
+			return "", nil, ErrInvalidTotpURI
+		}
+	}
+
+	if period := query.Get("period"); period != "" {
+		if options.Period, err = strconv.Atoi(period); err != nil {
+			return "", nil, ErrInvalidTotpURI
+		}
+	}
+
+	return secret, options, nil
+}
+
+// TotpCode computes the one-time password for the given secret at time t.
+// Only the algorithm, digits and period of the options are used.
+func TotpCode(secret string, options TotpGeneratorOptions, t time.Time) (string, error) {
+	key, err := decodeTotpSecret(secret)
+	if err != nil {
+		return "", err
+	}
+
+	var newHash func() hash.Hash
+	switch options.Algorithm {
+	case TotpAlgorithmSHA1:
+		newHash = sha1.New
+	case TotpAlgorithmSHA256:
+		newHash = sha256.New
+	case TotpAlgorithmSHA512:
+		newHash = sha512.New
+	default:
+		return "", ErrUnknownTotpAlgorithm
+	}
+
+	if options.Digits < totpMinDigits || options.Digits > totpMaxDigits {
+		return "", ErrTotpDigitsOutOfRange
+	}
+
+	if options.Period <= 0 {
+		return "", ErrTotpPeriodMustBeGreaterThanZero
+	}
+
+	counter := make([]byte, 8)
+	binary.BigEndian.PutUint64(counter, uint64(t.Unix()/int64(options.Period)))
+
+	mac := hmac.New(newHash, key)
+	mac.Write(counter)
+	sum := mac.Sum(nil)
+
+	// Dynamic truncation from RFC 4226 section 5.3.
+	offset := sum[len(sum)-1] & 0x0f
+	code := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	code %= uint32(math.Pow10(options.Digits))
+
+	return fmt.Sprintf("%0*d", options.Digits, code), nil
+}
+
+// escapeTotpLabel escapes a part of the label. The colon separates the issuer
+// from the account, but is left alone by url.PathEscape.
+func escapeTotpLabel(part string) string {
+	return strings.ReplaceAll(url.PathEscape(part), ":", "%3A")
+}
+
+func decodeTotpSecret(secret string) ([]byte, error) {
+	secret = strings.ToUpper(strings.Join(strings.Fields(secret), ""))
+	secret = strings.TrimRight(secret, "=")
+
+	key, err := totpEncoding.DecodeString(secret)
+	if err != nil || len(key) == 0 {
+		return nil, ErrInvalidTotpSecret
+	}
+
+	return key, nil
+}
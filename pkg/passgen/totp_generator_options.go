@@ -0,0 +1,69 @@
+package passgen
+
+import (
+	"errors"
+	"slices"
+)
+
+const (
+	TotpAlgorithmSHA1   = "SHA1"
+	TotpAlgorithmSHA256 = "SHA256"
+	TotpAlgorithmSHA512 = "SHA512"
+)
+
+const (
+	totpMinBytes  = 16
+	totpMinDigits = 6
+	totpMaxDigits = 8
+)
+
+var (
+	ErrTotpBytesTooShort               = errors.New("TOTP secret must be at least 16 bytes long.")
+	ErrUnknownTotpAlgorithm            = errors.New("TOTP algorithm must be one of SHA1, SHA256 or SHA512.")
+	ErrTotpDigitsOutOfRange            = errors.New("TOTP digits must be between 6 and 8.")
+	ErrTotpPeriodMustBeGreaterThanZero = errors.New("TOTP period must be greater than 0.")
+)
+
+var totpAlgorithms = []string{TotpAlgorithmSHA1, TotpAlgorithmSHA256, TotpAlgorithmSHA512}
+
+type TotpGeneratorOptions struct {
+	Bytes     int    `json:"bytes"`
+	Algorithm string `json:"algorithm"`
+	Digits    int    `json:"digits"`
+	Period    int    `json:"period"`
+	Issuer    string `json:"issuer"`
+	Account   string `json:"account"`
+
+	Random RandomSource `json:"-"`
+}
+
+func NewTotpGeneratorOptions() *TotpGeneratorOptions {
+	return &TotpGeneratorOptions{
+		Bytes:     20,
+		Algorithm: TotpAlgorithmSHA1,
+		Digits:    6,
+		Period:    30,
+		Issuer:    "",
+		Account:   "",
+	}
+}
+
+func (t *TotpGeneratorOptions) Validate() (bool, error) {
+	if t.Bytes < totpMinBytes {
+		return false, ErrTotpBytesTooShort
+	}
+
+	if !slices.Contains(totpAlgorithms, t.Algorithm) {
+		return false, ErrUnknownTotpAlgorithm
+	}
+
+	if t.Digits < totpMinDigits || t.Digits > totpMaxDigits {
+		return false, ErrTotpDigitsOutOfRange
+	}
+
+	if t.Period <= 0 {
+		return false, ErrTotpPeriodMustBeGreaterThanZero
+	}
+
+	return true, nil
+}
@@ -0,0 +1,43 @@
+package passgen
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTotpGeneratorOptions(t *testing.T) {
+	options := NewTotpGeneratorOptions()
+
+	assert.Equal(t, 20, options.Bytes)
+	assert.Equal(t, TotpAlgorithmSHA1, options.Algorithm)
+	assert.Equal(t, 6, options.Digits)
+	assert.Equal(t, 30, options.Period)
+	assert.Empty(t, options.Issuer)
+	assert.Empty(t, options.Account)
+}
+
+func TestTotpGeneratorOptionsValidate(t *testing.T) {
+	tests := []struct {
+		name           string
+		options        TotpGeneratorOptions
+		expectedResult bool
+		ErrWant        error
+	}{
+		{name: "valid options", options: TotpGeneratorOptions{Bytes: 32, Algorithm: TotpAlgorithmSHA256, Digits: 8, Period: 60}, expectedResult: true},
+		{name: "short secret", options: TotpGeneratorOptions{Bytes: 10, Algorithm: TotpAlgorithmSHA1, Digits: 6, Period: 30}, ErrWant: ErrTotpBytesTooShort},
+		{name: "unknown algorithm", options: TotpGeneratorOptions{Bytes: 20, Algorithm: "MD5", Digits: 6, Period: 30}, ErrWant: ErrUnknownTotpAlgorithm},
+		{name: "too few digits", options: TotpGeneratorOptions{Bytes: 20, Algorithm: TotpAlgorithmSHA1, Digits: 5, Period: 30}, ErrWant: ErrTotpDigitsOutOfRange},
+		{name: "too many digits", options: TotpGeneratorOptions{Bytes: 20, Algorithm: TotpAlgorithmSHA1, Digits: 9, Period: 30}, ErrWant: ErrTotpDigitsOutOfRange},
+		{name: "zero period", options: TotpGeneratorOptions{Bytes: 20, Algorithm: TotpAlgorithmSHA1, Digits: 6, Period: 0}, ErrWant: ErrTotpPeriodMustBeGreaterThanZero},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tt.options.Validate()
+
+			assert.Equal(t, tt.expectedResult, result)
+			assert.Equal(t, tt.ErrWant, err)
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package passgen
+
+import (
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateTotpSecret(t *testing.T) {
+	options := NewTotpGeneratorOptions()
+
+	secret, err := GenerateTotpSecret(*options)
+
+	require.NoError(t, err)
+	assert.Len(t, secret, 32)
+	assert.Regexp(t, "^[A-Z2-7]+$", secret)
+
+	other, err := GenerateTotpSecret(*options)
+	require.NoError(t, err)
+	assert.NotEqual(t, secret, other)
+
+	t.Run("invalid options", func(t *testing.T) {
+		_, err := GenerateTotpSecret(TotpGeneratorOptions{Bytes: 4})
+
+		assert.Equal(t, ErrTotpBytesTooShort, err)
+	})
+}
+
+// Test vectors from RFC 6238 appendix B.
+func TestTotpCode(t *testing.T) {
+	secrets := map[string]string{
+		TotpAlgorithmSHA1:   "12345678901234567890",
+		TotpAlgorithmSHA256: "12345678901234567890123456789012",
+		TotpAlgorithmSHA512: "1234567890123456789012345678901234567890123456789012345678901234",
+	}
+
+	tests := []struct {
+		unix      int64
+		algorithm string
+		expected  string
+	}{
+		{59, TotpAlgorithmSHA1, "94287082"},
+		{59, TotpAlgorithmSHA256, "46119246"},
+		{59, TotpAlgorithmSHA512, "90693936"},
+		{1111111109, TotpAlgorithmSHA1, "07081804"},
+		{1234567890, TotpAlgorithmSHA256, "91819424"},
+		{20000000000, TotpAlgorithmSHA512, "47863826"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.algorithm+"/"+tt.expected, func(t *testing.T) {
+			secret := base32.StdEncoding.EncodeToString([]byte(secrets[tt.algorithm]))
+			options := TotpGeneratorOptions{Algorithm: tt.algorithm, Digits: 8, Period: 30}
+
+			code, err := TotpCode(secret, options, time.Unix(tt.unix, 0))
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, code)
+		})
+	}
+
+	t.Run("invalid secret", func(t *testing.T) {
+		_, err := TotpCode("not base32!", *NewTotpGeneratorOptions(), time.Now())
+
+		assert.Equal(t, ErrInvalidTotpSecret, err)
+	})
+
+	t.Run("lowercase secret with spaces", func(t *testing.T) {
+		code, err := TotpCode("gezd gnbv gy3t qojq", *NewTotpGeneratorOptions(), time.Unix(59, 0))
+
+		require.NoError(t, err)
+		assert.Len(t, code, 6)
+	})
+}
+
+func TestTotpURI(t *testing.T) {
+	options := NewTotpGeneratorOptions()
+	options.Issuer = "ACME Co"
+	options.Account = "deploy@example.com"
+
+	uri := TotpURI("JBSWY3DPEHPK3PXP", *options)
+
+	assert.Equal(t, "otpauth://totp/ACME%20Co:deploy@example.com?algorithm=SHA1&digits=6&issuer=ACME%20Co&period=30&secret=JBSWY3DPEHPK3PXP", uri)
+
+	t.Run("colons are escaped", func(t *testing.T) {
+		options := NewTotpGeneratorOptions()
+		options.Issuer = "ACME: Cloud"
+		options.Account = "ci:deploy"
+
+		uri := TotpURI("JBSWY3DPEHPK3PXP", *options)
+
+		assert.True(t, strings.HasPrefix(uri, "otpauth://totp/ACME%3A%20Cloud:ci%3Adeploy?"), uri)
+	})
+
+	t.Run("plus signs are kept apart from spaces", func(t *testing.T) {
+		options := NewTotpGeneratorOptions()
+		options.Issuer = "C++ Co"
+		options.Account = "ci"
+
+		uri := TotpURI("JBSWY3DPEHPK3PXP", *options)
+
+		assert.Contains(t, uri, "&issuer=C%2B%2B%20Co&")
+		_, parsed, err := ParseTotpURI(uri)
+		require.NoError(t, err)
+		assert.Equal(t, "C++ Co", parsed.Issuer)
+	})
+}
+
+func TestParseTotpURI(t *testing.T) {
+	t.Run("round trip", func(t *testing.T) {
+		options := &TotpGeneratorOptions{Bytes: 20, Algorithm: TotpAlgorithmSHA256, Digits: 8, Period: 60, Issuer: "ACME: Cloud", Account: "ci:deploy"}
+		secret, err := GenerateTotpSecret(*options)
+		require.NoError(t, err)
+
+		parsedSecret, parsedOptions, err := ParseTotpURI(TotpURI(secret, *options))
+
+		require.NoError(t, err)
+		assert.Equal(t, secret, parsedSecret)
+		assert.Equal(t, options, parsedOptions)
+	})
+
+	t.Run("defaults for missing parameters", func(t *testing.T) {
+		secret, options, err := ParseTotpURI("otpauth://totp/alice?secret=JBSWY3DPEHPK3PXP")
+
+		require.NoError(t, err)
+		assert.Equal(t, "JBSWY3DPEHPK3PXP", secret)
+		assert.Equal(t, TotpAlgorithmSHA1, options.Algorithm)
+		assert.Equal(t, 6, options.Digits)
+		assert.Equal(t, 30, options.Period)
+		assert.Equal(t, "alice", options.Account)
+	})
+
+	t.Run("issuer from the label", func(t *testing.T) {
+		_, options, err := ParseTotpURI("otpauth://totp/ACME%3A%20Cloud:%20ci%3Adeploy?secret=JBSWY3DPEHPK3PXP")
+
+		require.NoError(t, err)
+		assert.Equal(t, "ACME: Cloud", options.Issuer)
+		assert.Equal(t, "ci:deploy", options.Account)
+	})
+
+	t.Run("missing parameters keep the given options", func(t *testing.T) {
+		base := TotpGeneratorOptions{Algorithm: TotpAlgorithmSHA256, Digits: 8, Period: 60, Issuer: "ACME", Account: "ci"}
+
+		_, options, err := ParseTotpURIWithOptions("otpauth://totp/alice?secret=JBSWY3DPEHPK3PXP&period=30", base)
+
+		require.NoError(t, err)
+		assert.Equal(t, &TotpGeneratorOptions{Bytes: 10, Algorithm: TotpAlgorithmSHA256, Digits: 8, Period: 30, Issuer: "ACME", Account: "alice"}, options)
+	})
+
+	t.Run("invalid URIs", func(t *testing.T) {
+		for _, uri := range []string{
+			"https://example.com/?secret=JBSWY3DPEHPK3PXP",
+			"otpauth://hotp/alice?secret=JBSWY3DPEHPK3PXP",
+			"otpauth://totp/alice",
+			"otpauth://totp/alice?secret=JBSWY3DPEHPK3PXP&digits=six",
+		} {
+			_, _, err := ParseTotpURI(uri)
+			assert.Equal(t, ErrInvalidTotpURI, err, uri)
+		}
+	})
+}
@@ -0,0 +1,103 @@
+package passgen
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const (
+	WifiSecurityWPA = "WPA"
+	WifiSecurityWEP = "WEP"
+	WifiSecuritySAE = "SAE"
+)
+
+const (
+	wifiMinPassphraseLength = 8
+	wifiMaxPassphraseLength = 63
+)
+
+var (
+	ErrWifiEmptySSID       = errors.New("Wi-Fi SSID should not be empty.")
+	ErrWifiEmptyPassword   = errors.New("Wi-Fi password should not be empty.")
+	ErrUnknownWifiSecurity = errors.New("Wi-Fi security must be one of WPA, WEP or SAE.")
+	ErrWifiPasswordLength  = errors.New("WPA and SAE Wi-Fi passwords must be between 8 and 63 bytes long.")
+)
+
+var wifiSecurityTypes = []string{WifiSecurityWPA, WifiSecurityWEP, WifiSecuritySAE}
+
+var wifiValueEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	`;`, `\;`,
+	`,`, `\,`,
+	`:`, `\:`,
+	`"`, `\"`,
+)
+
+// WifiCredentials describes a Wi-Fi network in the format understood by the
+// "join network" QR scanners of Android and iOS.
+type WifiCredentials struct {
+	SSID     string `json:"ssid"`
+	Password string `json:"password"`
+	Security string `json:"security"`
+	Hidden   bool   `json:"hidden"`
+}
+
+func NewWifiCredentials(ssid, password string) *WifiCredentials {
+	return &WifiCredentials{
+		SSID:     ssid,
+		Password: password,
+		Security: WifiSecurityWPA,
+		Hidden:   false,
+	}
+}
+
+func (w *WifiCredentials) Validate() (bool, error) {
+	if w.SSID == "" {
+		return false, ErrWifiEmptySSID
+	}
+
+	if w.Password == "" {
+		return false, ErrWifiEmptyPassword
+	}
+
+	if !slices.Contains(wifiSecurityTypes, w.Security) {
+		return false, ErrUnknownWifiSecurity
+	}
+
+	if err := CheckWifiPasswordLength(w.Security, len(w.Password)); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// CheckWifiPasswordLength reports whether a password of the given length in
+// bytes is too short or too long for the security type. WEP keys are not
+// checked.
+func CheckWifiPasswordLength(security string, length int) error {
+	if security != WifiSecurityWEP && (length < wifiMinPassphraseLength || length > wifiMaxPassphraseLength) {
+		return ErrWifiPasswordLength
+	}
+
+	return nil
+}
+
+// Payload returns the WIFI: string to encode in a QR code, e.g.
+// WIFI:T:WPA;S:guest;P:secret;;
+func (w *WifiCredentials) Payload() (string, error) {
+	if _, err := w.Validate(); err != nil {
+		return "", err
+	}
+
+	var payload strings.Builder
+
+	fmt.Fprintf(&payload, "WIFI:T:%s;S:%s;P:%s;", w.Security, wifiValueEscaper.Replace(w.SSID), wifiValueEscaper.Replace(w.Password))
+	if w.Hidden {
+		payload.WriteString("H:true;")
+	}
+	payload.WriteString(";")
+
+	return payload.String(), nil
+}
@@ -0,0 +1,92 @@
+package passgen
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewWifiCredentials(t *testing.T) {
+	credentials := NewWifiCredentials("guest", "secret12")
+
+	assert.Equal(t, "guest", credentials.SSID)
+	assert.Equal(t, "secret12", credentials.Password)
+	assert.Equal(t, WifiSecurityWPA, credentials.Security)
+	assert.False(t, credentials.Hidden)
+}
+
+func TestWifiCredentialsValidate(t *testing.T) {
+	tests := []struct {
+		name           string
+		credentials    WifiCredentials
+		expectedResult bool
+		ErrWant        error
+	}{
+		{name: "valid credentials", credentials: WifiCredentials{SSID: "guest", Password: "secret12", Security: WifiSecuritySAE}, expectedResult: true},
+		{name: "empty ssid", credentials: WifiCredentials{Password: "secret12", Security: WifiSecurityWPA}, ErrWant: ErrWifiEmptySSID},
+		{name: "empty password", credentials: WifiCredentials{SSID: "guest", Security: WifiSecurityWPA}, ErrWant: ErrWifiEmptyPassword},
+		{name: "short WPA password", credentials: WifiCredentials{SSID: "guest", Password: "short", Security: WifiSecurityWPA}, ErrWant: ErrWifiPasswordLength},
+		{name: "long multi-byte WPA password", credentials: WifiCredentials{SSID: "guest", Password: strings.Repeat("ä", 32), Security: WifiSecurityWPA}, ErrWant: ErrWifiPasswordLength},
+		{name: "short WEP password", credentials: WifiCredentials{SSID: "guest", Password: "short", Security: WifiSecurityWEP}, expectedResult: true},
+		{name: "unknown security", credentials: WifiCredentials{SSID: "guest", Password: "secret12", Security: "WPA3"}, ErrWant: ErrUnknownWifiSecurity},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tt.credentials.Validate()
+
+			assert.Equal(t, tt.expectedResult, result)
+			assert.Equal(t, tt.ErrWant, err)
+		})
+	}
+}
+
+func TestCheckWifiPasswordLength(t *testing.T) {
+	assert.Equal(t, ErrWifiPasswordLength, CheckWifiPasswordLength(WifiSecurityWPA, 7))
+	assert.NoError(t, CheckWifiPasswordLength(WifiSecuritySAE, 8))
+	assert.NoError(t, CheckWifiPasswordLength(WifiSecurityWPA, 63))
+	assert.Equal(t, ErrWifiPasswordLength, CheckWifiPasswordLength(WifiSecuritySAE, 64))
+	assert.NoError(t, CheckWifiPasswordLength(WifiSecurityWEP, 5))
+}
+
+func TestWifiCredentialsPayload(t *testing.T) {
+	tests := []struct {
+		name        string
+		credentials *WifiCredentials
+		expected    string
+	}{
+		{
+			name:        "plain values",
+			credentials: NewWifiCredentials("guest", "secret12"),
+			expected:    "WIFI:T:WPA;S:guest;P:secret12;;",
+		},
+		{
+			name:        "escaped special characters",
+			credentials: NewWifiCredentials(`my;net,"work"`, `pa:ss\wo;rd`),
+			expected:    `WIFI:T:WPA;S:my\;net\,\"work\";P:pa\:ss\\wo\;rd;;`,
+		},
+		{
+			name:        "hidden network",
+			credentials: &WifiCredentials{SSID: "office", Password: "secret12", Security: WifiSecurityWEP, Hidden: true},
+			expected:    "WIFI:T:WEP;S:office;P:secret12;H:true;;",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payload, err := tt.credentials.Payload()
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, payload)
+		})
+	}
+
+	t.Run("invalid credentials", func(t *testing.T) {
+		payload, err := NewWifiCredentials("", "secret").Payload()
+
+		assert.Equal(t, ErrWifiEmptySSID, err)
+		assert.Empty(t, payload)
+	})
+}